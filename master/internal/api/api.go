@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -12,7 +13,8 @@ import (
 const API_RESPONSE_CONTENT_TYPE = "application/json; charset=utf-8"
 
 func Initialize() {
-	setupRouter()
+	router := setupRouter()
+	router.Run(fmt.Sprintf(":%d", config.CONFIG_API_PORT))
 }
 
 func setupRouter() *gin.Engine {
@@ -23,8 +25,8 @@ func setupRouter() *gin.Engine {
 	router.GET("/game", getGame)
 	router.GET("/play", getPlay)
 	router.GET("/resign", getResign)
-
-	router.Run(fmt.Sprintf(":%d", config.CONFIG_API_PORT))
+	router.GET("/preview", getPreview)
+	setupRestRoutes(router)
 
 	return router
 }
@@ -69,7 +71,7 @@ func getPlay(c *gin.Context) {
 	if err != nil {
 		safeErrors := []error{game.ErrGameOver, game.ErrInvalidWord, game.ErrOutOfTurns}
 		for _, safe := range safeErrors {
-			if err == safe {
+			if errors.Is(err, safe) {
 				c.Data(http.StatusOK, API_RESPONSE_CONTENT_TYPE, []byte(out))
 				return
 			}
@@ -94,19 +96,52 @@ func getResign(c *gin.Context) {
 		return
 	}
 
+	// Resigning a finished game reports it unchanged
 	out, err := g.Resign()
-	if handleError(c, err) {
+	if err != nil && !errors.Is(err, game.ErrGameOver) {
+		handleError(c, err)
 		return
 	}
 
 	c.Data(http.StatusOK, API_RESPONSE_CONTENT_TYPE, []byte(out))
 }
 
+func getPreview(c *gin.Context) {
+	secretWord := c.Query("secret")
+	guessWord := c.Query("guess")
+
+	hints, err := game.PreviewHints(secretWord, guessWord)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"guess": guessWord, "hints": hints, "pattern": game.EncodeHints(hints)})
+}
+
+// Errors caused by the request, reported as 400 Bad Request
+var badRequestErrors = []error{
+	ErrInvalidId,
+	game.ErrWordLength,
+	game.ErrInvalidWord,
+	game.ErrAnagramRepeat,
+	game.ErrHardMode,
+}
+
 func handleError(c *gin.Context, err error) bool {
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return true
+	if err == nil {
+		return false
+	}
+
+	status := http.StatusInternalServerError
+	if errors.Is(err, game.ErrGameNotFound) {
+		status = http.StatusNotFound
+	}
+	for _, e := range badRequestErrors {
+		if errors.Is(err, e) {
+			status = http.StatusBadRequest
+		}
 	}
+	c.JSON(status, gin.H{"error": err.Error()})
 
-	return false
+	return true
 }
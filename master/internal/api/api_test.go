@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/game"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -245,3 +246,95 @@ func TestGetResign(t *testing.T) {
 	}
 	assert.EqualValues("Resigned", mapResult["gameStatus"])
 }
+
+func TestGetPreview(t *testing.T) {
+	tests := []struct {
+		secret  string
+		guess   string
+		pattern string
+		status  int
+	}{
+		{secret: "happy", guess: "heave", status: http.StatusOK},
+		{secret: "happy", guess: "happy", pattern: "GGGGG", status: http.StatusOK},
+		{secret: "happy", guess: "xxxxx", status: http.StatusBadRequest},
+		{secret: "xxxxx", guess: "happy", status: http.StatusBadRequest},
+		{secret: "", guess: "happy", status: http.StatusBadRequest},
+	}
+
+	assert := assert.New(t)
+
+	router := setupRouter()
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/preview", nil)
+		assert.NoError(err)
+
+		q := req.URL.Query()
+		q.Add("secret", test.secret)
+		q.Add("guess", test.guess)
+		req.URL.RawQuery = q.Encode()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(test.status, w.Code, test.secret+"/"+test.guess)
+		assert.Contains(w.Result().Header["Content-Type"], API_RESPONSE_CONTENT_TYPE)
+
+		mapResult := map[string]interface{}{}
+		assert.NoError(json.Unmarshal(w.Body.Bytes(), &mapResult))
+		if test.status != http.StatusOK {
+			assert.Contains(mapResult, "error")
+			continue
+		}
+		assert.Len(mapResult["hints"], config.CONFIG_GAME_WORDLENGTH)
+		if len(test.pattern) > 0 {
+			assert.Equal(test.pattern, mapResult["pattern"])
+		}
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		path   string
+		params map[string]string
+		status int
+	}{
+		{path: "/game", params: map[string]string{"word": "qzxvw"}, status: http.StatusBadRequest},
+		{path: "/game", params: map[string]string{"word": "happyday"}, status: http.StatusBadRequest},
+		{path: "/game", params: map[string]string{"id": "missing"}, status: http.StatusNotFound},
+		{path: "/play", params: map[string]string{"id": "<ID>", "guess": "alphabet"}, status: http.StatusBadRequest},
+		{path: "/play", params: map[string]string{"id": "missing", "guess": "happy"}, status: http.StatusNotFound},
+		{path: "/resign", params: map[string]string{}, status: http.StatusBadRequest},
+		{path: "/resign", params: map[string]string{"id": "missing"}, status: http.StatusNotFound},
+	}
+
+	assert := assert.New(t)
+	require := require.New(t)
+
+	router := setupRouter()
+	g, err := game.Create("happy")
+	require.NoError(err)
+	out := map[string]interface{}{}
+	s, err := g.Describe()
+	require.NoError(err)
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	gameId := out["id"].(string)
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", test.path, nil)
+		require.NoError(err)
+
+		q := req.URL.Query()
+		for k, v := range test.params {
+			q.Add(k, strings.Replace(v, "<ID>", gameId, 1))
+		}
+		req.URL.RawQuery = q.Encode()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(test.status, w.Code, req.URL.String())
+
+		mapResult := map[string]interface{}{}
+		assert.NoError(json.Unmarshal(w.Body.Bytes(), &mapResult))
+		assert.Contains(mapResult, "error", req.URL.String())
+	}
+}
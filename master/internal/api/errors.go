@@ -3,5 +3,7 @@ package api
 import "errors"
 
 var (
-	ErrInvalidId = errors.New("invalid id")
+	ErrInvalidId   = errors.New("invalid id")
+	ErrInvalidBody = errors.New("invalid request body")
+	ErrInvalidPage = errors.New("invalid page")
 )
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/game"
+	"github.com/gin-gonic/gin"
+)
+
+// Adds the RESTful game routes. Bodies are JSON; responses are the game's
+// own JSON reports.
+func setupRestRoutes(router *gin.Engine) {
+	router.GET("/games", getGamesList)
+	router.POST("/games", postGames)
+	router.GET("/games/:id", getGames)
+	router.POST("/games/:id/guesses", postGuesses)
+	router.POST("/games/:id/resign", postResign)
+}
+
+type createRequest struct {
+	Secret string `json:"secret"`
+}
+
+type guessRequest struct {
+	Guess string `json:"guess"`
+}
+
+// Returns a page of the game IDs, set by the offset and limit query parameters
+func getGamesList(c *gin.Context) {
+	offset, limit := 0, config.CONFIG_API_PAGELIMIT
+	var err error
+	if v := c.Query("offset"); len(v) > 0 {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			handleRestError(c, ErrInvalidPage)
+			return
+		}
+	}
+	if v := c.Query("limit"); len(v) > 0 {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			handleRestError(c, ErrInvalidPage)
+			return
+		}
+		if limit > config.CONFIG_API_PAGEMAXLIMIT {
+			limit = config.CONFIG_API_PAGEMAXLIMIT
+		}
+	}
+
+	ids, total, err := game.ListPaged(offset, limit)
+	if handleRestError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"games": ids, "total": total, "offset": offset, "limit": limit})
+}
+
+func postGames(c *gin.Context) {
+	var req createRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleRestError(c, ErrInvalidBody)
+			return
+		}
+	}
+
+	g, err := game.Create(req.Secret)
+	if handleRestError(c, err) {
+		return
+	}
+
+	out, err := g.DescribePublic()
+	if handleRestError(c, err) {
+		return
+	}
+
+	c.Data(http.StatusCreated, API_RESPONSE_CONTENT_TYPE, []byte(out))
+}
+
+func getGames(c *gin.Context) {
+	g, err := game.Retrieve(c.Param("id"))
+	if handleRestError(c, err) {
+		return
+	}
+
+	out, err := g.DescribePublic()
+	if handleRestError(c, err) {
+		return
+	}
+
+	c.Data(http.StatusOK, API_RESPONSE_CONTENT_TYPE, []byte(out))
+}
+
+func postGuesses(c *gin.Context) {
+	var req guessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleRestError(c, ErrInvalidBody)
+		return
+	}
+
+	g, err := game.Retrieve(c.Param("id"))
+	if handleRestError(c, err) {
+		return
+	}
+
+	out, err := g.Play(req.Guess)
+	if handleRestError(c, err) {
+		return
+	}
+	out, err = game.PublicReport(out)
+	if handleRestError(c, err) {
+		return
+	}
+
+	c.Data(http.StatusOK, API_RESPONSE_CONTENT_TYPE, []byte(out))
+}
+
+func postResign(c *gin.Context) {
+	g, err := game.Retrieve(c.Param("id"))
+	if handleRestError(c, err) {
+		return
+	}
+
+	out, err := g.Resign()
+	if handleRestError(c, err) {
+		return
+	}
+	out, err = game.PublicReport(out)
+	if handleRestError(c, err) {
+		return
+	}
+
+	c.Data(http.StatusOK, API_RESPONSE_CONTENT_TYPE, []byte(out))
+}
+
+// Errors mapped to a status by the RESTful routes; others are reported as
+// 500 Internal Server Error
+var restErrorStatus = []struct {
+	err    error
+	status int
+}{
+	{ErrInvalidBody, http.StatusBadRequest},
+	{ErrInvalidPage, http.StatusBadRequest},
+	{game.ErrGameNotFound, http.StatusNotFound},
+	{game.ErrGameOver, http.StatusConflict},
+	{game.ErrOutOfTurns, http.StatusConflict},
+	{game.ErrWordLength, http.StatusUnprocessableEntity},
+	{game.ErrInvalidWord, http.StatusUnprocessableEntity},
+	{game.ErrAnagramRepeat, http.StatusUnprocessableEntity},
+	{game.ErrHardMode, http.StatusUnprocessableEntity},
+}
+
+func handleRestError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	status := http.StatusInternalServerError
+	for _, e := range restErrorStatus {
+		if errors.Is(err, e.err) {
+			status = e.status
+			break
+		}
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+
+	return true
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Serves a request with a JSON body, returning the recorder and decoded body
+func serveJSON(t *testing.T, method string, path string, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	router := setupRouter()
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(method, path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	mapResult := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &mapResult), w.Body.String())
+
+	return w, mapResult
+}
+
+func TestPostGames(t *testing.T) {
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{body: "", status: http.StatusCreated},
+		{body: `{}`, status: http.StatusCreated},
+		{body: `{"secret": "happy"}`, status: http.StatusCreated},
+		{body: `{"secret": "qzxvw"}`, status: http.StatusUnprocessableEntity},
+		{body: `{"secret": "happyday"}`, status: http.StatusUnprocessableEntity},
+		{body: `{"secret": `, status: http.StatusBadRequest},
+	}
+
+	assert := assert.New(t)
+
+	for _, test := range tests {
+		w, mapResult := serveJSON(t, "POST", "/games", test.body)
+		assert.Equal(test.status, w.Code, test.body)
+		assert.Contains(w.Result().Header["Content-Type"], API_RESPONSE_CONTENT_TYPE)
+		if test.status != http.StatusCreated {
+			assert.Contains(mapResult, "error", test.body)
+			continue
+		}
+
+		for _, elem := range []string{"id", "gameStatus", "attempts"} {
+			assert.Contains(mapResult, elem, test.body)
+		}
+	}
+}
+
+func TestGetGames(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	w, created := serveJSON(t, "POST", "/games", `{"secret": "happy"}`)
+	require.Equal(http.StatusCreated, w.Code)
+	gameId := created["id"].(string)
+
+	w, mapResult := serveJSON(t, "GET", "/games/"+gameId, "")
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal(gameId, mapResult["id"])
+	assert.EqualValues("InPlay", mapResult["gameStatus"])
+	assert.NotContains(mapResult, "secretWord")
+	assert.NotContains(created, "secretWord")
+
+	w, mapResult = serveJSON(t, "GET", "/games/missing", "")
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Contains(mapResult, "error")
+}
+
+func TestPostGuesses(t *testing.T) {
+	tests := []struct {
+		id     string
+		body   string
+		status int
+		result string
+	}{
+		{id: "<ID>", body: `{"guess": "heave"}`, status: http.StatusOK, result: "InPlay"},
+		{id: "<ID>", body: `{"guess": "xxxxx"}`, status: http.StatusUnprocessableEntity},
+		{id: "<ID>", body: `{"guess": "alphabet"}`, status: http.StatusUnprocessableEntity},
+		{id: "<ID>", body: `{"guess": `, status: http.StatusBadRequest},
+		{id: "missing", body: `{"guess": "happy"}`, status: http.StatusNotFound},
+		{id: "<ID>", body: `{"guess": "happy"}`, status: http.StatusOK, result: "Won"},
+		{id: "<ID>", body: `{"guess": "heave"}`, status: http.StatusConflict},
+	}
+
+	assert := assert.New(t)
+	require := require.New(t)
+
+	w, created := serveJSON(t, "POST", "/games", `{"secret": "happy"}`)
+	require.Equal(http.StatusCreated, w.Code)
+	gameId := created["id"].(string)
+
+	for _, test := range tests {
+		path := "/games/" + strings.Replace(test.id, "<ID>", gameId, 1) + "/guesses"
+		w, mapResult := serveJSON(t, "POST", path, test.body)
+		assert.Equal(test.status, w.Code, test.body)
+		assert.Contains(w.Result().Header["Content-Type"], API_RESPONSE_CONTENT_TYPE)
+		if test.status != http.StatusOK {
+			assert.Contains(mapResult, "error", test.body)
+			continue
+		}
+		assert.EqualValues(test.result, mapResult["gameStatus"], test.body)
+	}
+}
+
+func TestPostResign(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	w, created := serveJSON(t, "POST", "/games", "")
+	require.Equal(http.StatusCreated, w.Code)
+	gameId := created["id"].(string)
+
+	w, mapResult := serveJSON(t, "POST", "/games/"+gameId+"/resign", "")
+	assert.Equal(http.StatusOK, w.Code)
+	assert.EqualValues("Resigned", mapResult["gameStatus"])
+
+	// The game is already finished
+	w, mapResult = serveJSON(t, "POST", "/games/"+gameId+"/resign", "")
+	assert.Equal(http.StatusConflict, w.Code)
+	assert.Contains(mapResult, "error")
+
+	w, _ = serveJSON(t, "POST", "/games/missing/resign", "")
+	assert.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestRestHidesNote(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	w, created := serveJSON(t, "POST", "/games", `{"secret": "happy"}`)
+	require.Equal(http.StatusCreated, w.Code)
+	gameId := created["id"].(string)
+	g, err := game.Retrieve(gameId)
+	require.NoError(err)
+	require.NoError(g.SetNote("private"))
+
+	w, mapResult := serveJSON(t, "GET", "/games/"+gameId, "")
+	require.Equal(http.StatusOK, w.Code)
+	assert.NotContains(mapResult, "note")
+
+	w, mapResult = serveJSON(t, "POST", "/games/"+gameId+"/guesses", `{"guess": "heave"}`)
+	require.Equal(http.StatusOK, w.Code)
+	assert.NotContains(mapResult, "note")
+	assert.Len(mapResult["attempts"], 1)
+
+	w, mapResult = serveJSON(t, "POST", "/games/"+gameId+"/resign", "")
+	require.Equal(http.StatusOK, w.Code)
+	assert.NotContains(mapResult, "note")
+	assert.EqualValues("Resigned", mapResult["gameStatus"])
+
+	// The note is still kept with the game
+	g, err = game.Retrieve(gameId)
+	require.NoError(err)
+	s, err := g.Describe()
+	require.NoError(err)
+	assert.Contains(s, `"note":"private"`)
+}
+
+func TestGetGamesList(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	created := []string{}
+	for i := 0; i < 3; i++ {
+		w, mapResult := serveJSON(t, "POST", "/games", "")
+		require.Equal(http.StatusCreated, w.Code)
+		created = append(created, mapResult["id"].(string))
+	}
+
+	// Games from other tests share the store, so compare against the total.
+	// Pages are capped, however large a limit is asked for.
+	all := []string{}
+	total := 0
+	for offset := 0; offset == 0 || offset < total; offset += config.CONFIG_API_PAGEMAXLIMIT {
+		w, mapResult := serveJSON(t, "GET", fmt.Sprintf("/games?offset=%d&limit=%d", offset, math.MaxInt), "")
+		require.Equal(http.StatusOK, w.Code)
+		assert.EqualValues(config.CONFIG_API_PAGEMAXLIMIT, mapResult["limit"])
+		total = int(mapResult["total"].(float64))
+		for _, v := range mapResult["games"].([]interface{}) {
+			all = append(all, v.(string))
+		}
+	}
+	assert.Len(all, total)
+	assert.Subset(all, created)
+	assert.IsIncreasing(all)
+
+	w, mapResult := serveJSON(t, "GET", "/games?offset=1&limit=2", "")
+	require.Equal(http.StatusOK, w.Code)
+	assert.Equal([]interface{}{all[1], all[2]}, mapResult["games"])
+	assert.EqualValues(total, mapResult["total"])
+
+	// Out of range offsets return an empty page with the total
+	w, mapResult = serveJSON(t, "GET", fmt.Sprintf("/games?offset=%d", total), "")
+	require.Equal(http.StatusOK, w.Code)
+	assert.Empty(mapResult["games"])
+	assert.EqualValues(total, mapResult["total"])
+
+	for _, q := range []string{"offset=-1", "offset=x", "limit=0", "limit=x"} {
+		w, mapResult = serveJSON(t, "GET", "/games?"+q, "")
+		assert.Equal(http.StatusBadRequest, w.Code, q)
+		assert.Contains(mapResult, "error", q)
+	}
+}
@@ -6,16 +6,41 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 const CONFIG_API_PORT = 8080
+const CONFIG_API_PAGELIMIT = 20
+const CONFIG_API_PAGEMAXLIMIT = 100
 
 // const CONFIG_DICTIONARY_FILENAME = "google-10000-english-usa-no-swears-medium.txt"
 const CONFIG_DICTIONARY_FILENAME = "corncob_lowercase.txt"
 const CONFIG_DICTIONARY_FILEPATH = "data/" + CONFIG_DICTIONARY_FILENAME
+
+// Frequency-ordered word list used to judge how common a word is
+const CONFIG_FREQUENCY_FILEPATH = "data/google-10000-english-usa-no-swears-medium.txt"
 const CONFIG_GAME_WORDLENGTH = 5
 const CONFIG_GAME_MAXATTEMPTS = 12
 const CONFIG_GAME_MAXVALIDATTEMPTS = 6
+const CONFIG_GAME_MAXNOTELENGTH = 500
+const CONFIG_GAME_BATCHWORKERS = 4
+const CONFIG_SOLVER_CONCURRENCY = 4
+const CONFIG_SOLVER_OPENER = "arise"
+const CONFIG_FAIRNESS_MAXNEIGHBORS = 5
+const CONFIG_FAIRNESS_MAXRARITY = 0.9
+
+// Symbols used for accessible rendering, in the order green, yellow, grey
+const CONFIG_COLORBLIND_SYMBOLS = "■◐□"
+
+// How often the in-memory store deletes content whose TTL has elapsed
+const CONFIG_STORE_SWEEPINTERVAL = time.Minute
+
+// When set, any alphabetic string of the correct length is accepted as a
+// guess or secret without checking the dictionary.
+var CONFIG_RELAXED_VALIDATION = false
+
+// Date of daily puzzle number 0. Daily puzzles are numbered by days since this date.
+var CONFIG_DAILY_EPOCH = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
 
 func RootDir() string {
 	_, b, _, _ := runtime.Caller(0)
@@ -3,5 +3,6 @@ package config
 import "errors"
 
 var (
-	ErrFilepath = errors.New("invalid filepath")
+	ErrFilepath      = errors.New("invalid filepath")
+	ErrInvalidConfig = errors.New("invalid configuration")
 )
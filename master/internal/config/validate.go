@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lists every problem found while validating the configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidConfig, strings.Join(e.Problems, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// Checks that the configuration is consistent. Intended to be called once at
+// service startup; the returned error describes all problems found.
+func Validate() error {
+	return validate(settings{
+		apiPort:           CONFIG_API_PORT,
+		dictionaryPath:    CONFIG_DICTIONARY_FILEPATH,
+		wordLength:        CONFIG_GAME_WORDLENGTH,
+		maxAttempts:       CONFIG_GAME_MAXATTEMPTS,
+		maxValidAttempts:  CONFIG_GAME_MAXVALIDATTEMPTS,
+		solverOpener:      CONFIG_SOLVER_OPENER,
+		solverConcurrency: CONFIG_SOLVER_CONCURRENCY,
+		batchWorkers:      CONFIG_GAME_BATCHWORKERS,
+		colorblindSymbols: CONFIG_COLORBLIND_SYMBOLS,
+		maxRarity:         CONFIG_FAIRNESS_MAXRARITY,
+	})
+}
+
+type settings struct {
+	apiPort           int
+	dictionaryPath    string
+	wordLength        int
+	maxAttempts       int
+	maxValidAttempts  int
+	solverOpener      string
+	solverConcurrency int
+	batchWorkers      int
+	colorblindSymbols string
+	maxRarity         float64
+}
+
+func validate(c settings) error {
+	problems := []string{}
+	add := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	if c.apiPort < 1 || c.apiPort > 65535 {
+		add("api port %d is out of range", c.apiPort)
+	}
+	if f, err := LoadEmbedFile(c.dictionaryPath); err != nil {
+		add("dictionary %q can't be loaded", c.dictionaryPath)
+	} else {
+		f.Close()
+	}
+	if c.wordLength < 1 {
+		add("word length %d must be at least 1", c.wordLength)
+	}
+	if c.maxValidAttempts < 1 {
+		add("max valid attempts %d must be at least 1", c.maxValidAttempts)
+	}
+	if c.maxAttempts < c.maxValidAttempts {
+		add("max attempts %d is less than max valid attempts %d", c.maxAttempts, c.maxValidAttempts)
+	}
+	if len(c.solverOpener) != c.wordLength {
+		add("solver opener %q doesn't match the word length %d", c.solverOpener, c.wordLength)
+	}
+	if c.solverConcurrency < 1 {
+		add("solver concurrency %d must be at least 1", c.solverConcurrency)
+	}
+	if c.batchWorkers < 1 {
+		add("batch workers %d must be at least 1", c.batchWorkers)
+	}
+	if r := []rune(c.colorblindSymbols); len(r) != 3 || r[0] == r[1] || r[0] == r[2] || r[1] == r[2] {
+		add("colorblind symbols %q must be three distinct symbols", c.colorblindSymbols)
+	}
+	if c.maxRarity < 0 || c.maxRarity > 1 {
+		add("max rarity %g must be between 0 and 1", c.maxRarity)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	// The shipped configuration is valid
+	assert.NoError(Validate())
+
+	valid := settings{
+		apiPort:           8080,
+		dictionaryPath:    CONFIG_DICTIONARY_FILEPATH,
+		wordLength:        5,
+		maxAttempts:       12,
+		maxValidAttempts:  6,
+		solverOpener:      "arise",
+		solverConcurrency: 4,
+		batchWorkers:      4,
+		colorblindSymbols: "■◐□",
+		maxRarity:         0.9,
+	}
+	assert.NoError(validate(valid))
+
+	tests := []struct {
+		change   func(c *settings)
+		problems []string
+	}{
+		{change: func(c *settings) { c.apiPort = 0 }, problems: []string{"api port 0 is out of range"}},
+		{change: func(c *settings) { c.dictionaryPath = "data/missing.txt" }, problems: []string{"dictionary \"data/missing.txt\" can't be loaded"}},
+		{change: func(c *settings) { c.maxAttempts = 3 }, problems: []string{"max attempts 3 is less than max valid attempts 6"}},
+		{change: func(c *settings) { c.wordLength = 0 }, problems: []string{
+			"word length 0 must be at least 1",
+			"solver opener \"arise\" doesn't match the word length 0",
+		}},
+		{change: func(c *settings) { c.maxValidAttempts = 0; c.solverConcurrency = 0 }, problems: []string{
+			"max valid attempts 0 must be at least 1",
+			"solver concurrency 0 must be at least 1",
+		}},
+		{change: func(c *settings) { c.batchWorkers = 0 }, problems: []string{"batch workers 0 must be at least 1"}},
+		{change: func(c *settings) { c.colorblindSymbols = "■■□" }, problems: []string{"colorblind symbols \"■■□\" must be three distinct symbols"}},
+		{change: func(c *settings) { c.maxRarity = 2 }, problems: []string{"max rarity 2 must be between 0 and 1"}},
+	}
+
+	for _, test := range tests {
+		c := valid
+		test.change(&c)
+
+		err := validate(c)
+		assert.ErrorIs(err, ErrInvalidConfig)
+
+		var verr *ValidationError
+		if assert.True(errors.As(err, &verr)) {
+			assert.Equal(test.problems, verr.Problems)
+		}
+		for _, p := range test.problems {
+			assert.Contains(err.Error(), p)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package dictionary
+
+import (
+	"time"
+
+	"aluance.io/wordleserver/internal/config"
+)
+
+// Returns the daily puzzle number for a date, counted in days since
+// config.CONFIG_DAILY_EPOCH. Only the calendar date is considered.
+func DailyNumber(date time.Time) (int, error) {
+	y, m, d := date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	y, m, d = config.CONFIG_DAILY_EPOCH.Date()
+	epoch := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	if day.Before(epoch) {
+		return 0, ErrBeforeEpoch
+	}
+
+	return int(day.Sub(epoch).Hours() / 24), nil
+}
+
+// Returns the daily puzzle word for a date. The same date always maps to the
+// same word for a given dictionary. Once chosen, the word is pinned to the
+// date, so changing the dictionary at runtime only affects later dates.
+func DailyWord(date time.Time) (string, error) {
+	number, err := DailyNumber(date)
+	if err != nil {
+		return "", err
+	}
+
+	if w, ok := wordleDict.pinnedDaily(number); ok {
+		return w, nil
+	}
+	w, err := GenerateWordSeeded(int64(number))
+	if err != nil {
+		return "", err
+	}
+
+	return wordleDict.pinDaily(number, w), nil
+}
@@ -0,0 +1,97 @@
+package dictionary
+
+import (
+	"testing"
+	"time"
+
+	"aluance.io/wordleserver/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(e time.Time) { config.CONFIG_DAILY_EPOCH = e }(config.CONFIG_DAILY_EPOCH)
+	config.CONFIG_DAILY_EPOCH = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		date   time.Time
+		result int
+		err    error
+	}{
+		{date: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), result: 0},
+		{date: time.Date(2022, time.January, 1, 23, 59, 0, 0, time.UTC), result: 0},
+		{date: time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC), result: 1},
+		{date: time.Date(2022, time.January, 3, 12, 0, 0, 0, time.UTC), result: 2},
+		{date: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), result: 365},
+		{date: time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC), err: ErrBeforeEpoch},
+	}
+
+	for _, test := range tests {
+		n, err := DailyNumber(test.date)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err)
+		assert.Equal(test.result, n, test.date.String())
+	}
+
+	// Consecutive days increment by one
+	date := config.CONFIG_DAILY_EPOCH
+	for i := 0; i < 10; i++ {
+		n, err := DailyNumber(date.AddDate(0, 0, i))
+		assert.NoError(err)
+		assert.Equal(i, n)
+	}
+}
+
+func TestDailyWord(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	day1 := time.Date(2022, time.February, 1, 8, 0, 0, 0, time.UTC)
+	day2 := time.Date(2022, time.February, 2, 8, 0, 0, 0, time.UTC)
+
+	w1, err := DailyWord(day1)
+	require.NoError(err)
+	assert.Equal(config.CONFIG_GAME_WORDLENGTH, len(w1))
+	assert.True(IsWordValid(w1))
+
+	// The same date yields the same word
+	again, err := DailyWord(day1.Add(10 * time.Hour))
+	assert.NoError(err)
+	assert.Equal(w1, again)
+
+	w2, err := DailyWord(day2)
+	require.NoError(err)
+	assert.True(IsWordValid(w2))
+
+	// Words are stable across runs for the same dictionary and epoch
+	assert.Equal("plaza", w1)
+	assert.Equal("davis", w2)
+
+	// Changing the dictionary doesn't change a word already chosen
+	require.NoError(RemoveWord(w1))
+	require.NoError(AddWord("qophs"))
+	again, err = DailyWord(day1)
+	require.NoError(err)
+	assert.Equal(w1, again)
+	require.NoError(Reload(TEST_DICTIONARY_FILEPATH))
+	again, err = DailyWord(day2)
+	require.NoError(err)
+	assert.Equal(w2, again)
+
+	_, err = DailyWord(config.CONFIG_DAILY_EPOCH.AddDate(0, 0, -1))
+	assert.ErrorIs(err, ErrBeforeEpoch)
+
+	wordleDict.reset()
+	wordleDict.initalized = true // an empty dictionary
+	defer wordleDict.reset()
+	_, err = DailyWord(day1)
+	assert.ErrorIs(err, ErrEmptyDictionary)
+}
@@ -2,9 +2,15 @@ package dictionary
 
 import (
 	"bufio"
+	"errors"
+	"io"
+	"io/fs"
 	"math/rand"
+	"os"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"aluance.io/wordleserver/internal/config"
 	"github.com/matryer/resync"
@@ -16,26 +22,160 @@ func GenerateWord() (string, error) {
 	}
 
 	word := "blank"
-	if max := wordleDict.size(); max > 0 {
+	words := wordleDict.answers()
+	if max := len(words); max > 0 {
 		index := rand.Intn(max)
-		word = wordleDict.words[index]
+		word = words[index]
 	}
 
 	return word, nil
 }
 
+// Generates a word using its own source seeded with seed, so the same seed
+// always generates the same word from the same dictionary
+func GenerateWordSeeded(seed int64) (string, error) {
+	if err := Initialize(""); err != nil {
+		return "", err
+	}
+
+	words := wordleDict.answers()
+	max := len(words)
+	if max < 1 {
+		return "", ErrEmptyDictionary
+	}
+	index := rand.New(rand.NewSource(seed)).Intn(max)
+
+	return words[index], nil
+}
+
+// Generates a word of the given length. Words of the configured length come
+// from the main word list, others from the other-length words of the same file.
+func GenerateWordOfLength(n int) (string, error) {
+	if n == config.CONFIG_GAME_WORDLENGTH {
+		return GenerateWord()
+	}
+	if err := Initialize(""); err != nil {
+		return "", err
+	}
+
+	words := wordleDict.ofLength(n)
+	if len(words) < 1 {
+		return "", ErrEmptyDictionary
+	}
+
+	return words[rand.Intn(len(words))], nil
+}
+
 func IsWordValid(w string) bool {
 	if err := Initialize(""); err != nil {
 		return false
 	}
 
-	if member, ok := wordleDict.wordMap[strings.ToLower(w)]; ok {
-		return member
+	w = strings.ToLower(w)
+	wordleDict.mu.RLock()
+	defer wordleDict.mu.RUnlock()
+	if utf8.RuneCountInString(w) != config.CONFIG_GAME_WORDLENGTH {
+		return wordleDict.otherMap[w]
+	}
+
+	return wordleDict.wordMap[w] || wordleDict.guessMap[w]
+}
+
+// Returns a copy of the words in the dictionary
+func Words() ([]string, error) {
+	if err := Initialize(""); err != nil {
+		return nil, err
+	}
+
+	answers := wordleDict.answers()
+	words := make([]string, len(answers))
+	copy(words, answers)
+
+	return words, nil
+}
+
+// Returns a copy of the dictionary words of length n
+func WordsOfLength(n int) ([]string, error) {
+	if n == config.CONFIG_GAME_WORDLENGTH {
+		return Words()
+	}
+	if err := Initialize(""); err != nil {
+		return nil, err
+	}
+
+	return append([]string{}, wordleDict.ofLength(n)...), nil
+}
+
+// Returns the frequency rank of a word (0 is most common). Words are ranked by
+// their order in the dictionary file, so this is only meaningful for
+// frequency-ordered lists.
+func Rank(w string) (int, error) {
+	if err := Initialize(""); err != nil {
+		return 0, err
+	}
+
+	wordleDict.mu.RLock()
+	defer wordleDict.mu.RUnlock()
+	if rank, ok := wordleDict.ranks[strings.ToLower(w)]; ok {
+		return rank, nil
+	}
+
+	return 0, ErrUnknownWord
+}
+
+// Returns the dictionary words that differ from w in exactly one position
+func Neighbors(w string) ([]string, error) {
+	if err := Initialize(""); err != nil {
+		return nil, err
+	}
+
+	r := []rune(strings.ToLower(w))
+	neighbors := []string{}
+	for _, word := range wordleDict.answers() {
+		wr := []rune(word)
+		if len(wr) != len(r) {
+			continue
+		}
+		diff := 0
+		for i := 0; i < len(r) && diff < 2; i++ {
+			if wr[i] != r[i] {
+				diff++
+			}
+		}
+		if diff == 1 {
+			neighbors = append(neighbors, word)
+		}
+	}
+
+	return neighbors, nil
+}
+
+// Returns the fraction of dictionary words starting with each letter, for
+// auditing bias in the secret words
+func FirstLetterDistribution() map[rune]float64 {
+	dist := map[rune]float64{}
+	if err := Initialize(""); err != nil {
+		return dist
+	}
+	words := wordleDict.answers()
+	if len(words) < 1 {
+		return dist
+	}
+
+	for _, word := range words {
+		r, _ := utf8.DecodeRuneInString(word)
+		dist[r]++
+	}
+	for r := range dist {
+		dist[r] /= float64(len(words))
 	}
 
-	return false
+	return dist
 }
 
+// Initializes the dictionary from the word list in filename. An empty
+// filename uses the default list embedded in the binary, so no files are
+// needed at runtime.
 func Initialize(filename string) error {
 
 	// Only initialized dictionary once
@@ -43,56 +183,364 @@ func Initialize(filename string) error {
 		return nil
 	}
 
-	if len(filename) < 1 {
-		filename = config.CONFIG_DICTIONARY_FILEPATH
-	}
-
-	f, err := config.LoadEmbedFile(filename)
+	f, err := openWordList(filename)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
+	return InitializeFromReader(f)
+}
+
+// Initializes the dictionary from newline-separated words read from r, such
+// as a word list embedded in the binary. Words are lowercased, and only words
+// of the configured length, counted in letters rather than bytes, can be
+// secret words. Like Initialize, it has no effect once the dictionary is
+// initialized.
+func InitializeFromReader(r io.Reader) error {
+	if wordleDict.initalized {
+		return nil
+	}
+
 	// Do this only once (unless reset)
+	var err error
 	wordleDict.init_once.Do(func() {
 		rand.Seed(time.Now().UnixNano())
 
-		// Load only words of configured length from the file
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			word := scanner.Text()
-			if len(word) == config.CONFIG_GAME_WORDLENGTH {
-				wordleDict.words = append(wordleDict.words, word)
-				wordleDict.wordMap[word] = true
-			}
-		}
-
-		if err := scanner.Err(); err != nil {
+		var l *wordList
+		if l, err = readWordList(r); err != nil {
 			return
 		}
+		wordleDict.setWords(l)
 
 		wordleDict.initalized = true
 	})
+	if err != nil {
+		// Leave the dictionary empty so it can be initialized again
+		wordleDict.reset()
+		return err
+	}
+
+	return nil
+}
+
+// Replaces the answer words, and the words of other lengths, with the word
+// list in filename, read as by Initialize. Readers see either the old or the
+// new list, never a mix of both. The guess list is kept, while words added by
+// AddWord are dropped. Games in play keep their secret word even if it is no
+// longer in the list. A list that can't be read, or that has no answer words,
+// leaves the dictionary unchanged.
+func Reload(filename string) error {
+	if err := Initialize(""); err != nil {
+		return err
+	}
+
+	f, err := openWordList(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	l, err := readWordList(f)
+	if err != nil {
+		return err
+	}
+	if len(l.words) < 1 {
+		return ErrEmptyDictionary
+	}
+	wordleDict.setWords(l)
+
+	return nil
+}
+
+// Loads a list of words that are valid guesses but never chosen as secret
+// words. Words are lowercased, as with Initialize. May be called again to
+// replace the list.
+func InitializeGuesses(filename string) error {
+	if len(filename) < 1 {
+		return config.ErrFilepath
+	}
+	f, err := openWordList(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	guesses := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if utf8.RuneCountInString(word) == config.CONFIG_GAME_WORDLENGTH {
+			guesses = append(guesses, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	guessMap := make(map[string]bool, len(guesses))
+	for _, word := range guesses {
+		guessMap[word] = true
+	}
+
+	wordleDict.mu.Lock()
+	defer wordleDict.mu.Unlock()
+	wordleDict.guesses = guesses
+	wordleDict.guessMap = guessMap
+
+	return nil
+}
+
+// Returns the words of the guess list that are not in the answer list
+func GuessOnlyWords() []string {
+	words := []string{}
+	if err := Initialize(""); err != nil {
+		return words
+	}
+
+	wordleDict.mu.RLock()
+	defer wordleDict.mu.RUnlock()
+	for _, word := range wordleDict.guesses {
+		if !wordleDict.wordMap[word] {
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+// Adds an answer word at runtime. The word becomes valid, and may be
+// generated, immediately. Adding a word already present has no effect.
+func AddWord(word string) error {
+	if err := Initialize(""); err != nil {
+		return err
+	}
+
+	word = strings.ToLower(strings.TrimSpace(word))
+	if utf8.RuneCountInString(word) != config.CONFIG_GAME_WORDLENGTH {
+		return ErrWordLength
+	}
+
+	wordleDict.mu.Lock()
+	defer wordleDict.mu.Unlock()
+	if wordleDict.wordMap[word] {
+		return nil
+	}
+
+	// Replace rather than append to the slice, which readers may be iterating
+	words := make([]string, len(wordleDict.words), len(wordleDict.words)+1)
+	copy(words, wordleDict.words)
+	wordleDict.ranks[word] = len(words)
+	wordleDict.words = append(words, word)
+	wordleDict.wordMap[word] = true
+	solverCache.reset()
+	rankCache.reset()
+
+	return nil
+}
+
+// Removes an answer word at runtime, so it is no longer generated. It stays a
+// valid guess if it is also in the guess list.
+func RemoveWord(word string) error {
+	if err := Initialize(""); err != nil {
+		return err
+	}
+
+	word = strings.ToLower(strings.TrimSpace(word))
+
+	wordleDict.mu.Lock()
+	defer wordleDict.mu.Unlock()
+	if !wordleDict.wordMap[word] {
+		return ErrUnknownWord
+	}
+
+	words := make([]string, 0, len(wordleDict.words)-1)
+	ranks := make(map[string]int, len(wordleDict.words)-1)
+	for _, w := range wordleDict.words {
+		if w != word {
+			ranks[w] = len(words)
+			words = append(words, w)
+		}
+	}
+	wordleDict.words = words
+	wordleDict.ranks = ranks
+	delete(wordleDict.wordMap, word)
+	solverCache.reset()
+	rankCache.reset()
 
 	return nil
 }
 
+// Opens a word list, reading filename from disk so deployments can override
+// the bundled lists. Names not found on disk, such as the data/ paths of the
+// bundled lists, are read from the files embedded in the binary.
+func openWordList(filename string) (io.ReadCloser, error) {
+	if len(filename) < 1 {
+		return config.LoadEmbedFile(config.CONFIG_DICTIONARY_FILEPATH)
+	}
+
+	f, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config.LoadEmbedFile(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// The words read from a word list file
+type wordList struct {
+	words    []string
+	wordMap  map[string]bool
+	ranks    map[string]int
+	others   map[int][]string
+	otherMap map[string]bool
+}
+
+// Reads newline-separated words from r. Words are lowercased, and only words
+// of the configured length are answers. Others are kept by length, for games
+// using another word length.
+func readWordList(r io.Reader) (*wordList, error) {
+	l := &wordList{
+		words:    []string{},
+		wordMap:  make(map[string]bool),
+		ranks:    make(map[string]int),
+		others:   make(map[int][]string),
+		otherMap: make(map[string]bool),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		n := utf8.RuneCountInString(word)
+		if n == config.CONFIG_GAME_WORDLENGTH {
+			if l.wordMap[word] {
+				continue
+			}
+			l.ranks[word] = len(l.words)
+			l.words = append(l.words, word)
+			l.wordMap[word] = true
+		} else if n > 0 && !l.otherMap[word] {
+			l.others[n] = append(l.others[n], word)
+			l.otherMap[word] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
 type dict struct {
 	init_once  resync.Once
 	initalized bool
-	words      []string
-	wordMap    map[string]bool
+
+	// Guards the word lists, which AddWord, RemoveWord, Reload and
+	// InitializeGuesses change, and the pinned daily words. Slices are
+	// replaced, never modified in place, once initialized.
+	mu      sync.RWMutex
+	words   []string
+	wordMap map[string]bool
+	ranks   map[string]int
+
+	guesses  []string // valid guesses, not necessarily answers
+	guessMap map[string]bool
+	others   map[int][]string // words of other lengths, by length
+	otherMap map[string]bool
+
+	daily map[int]string // daily words already chosen, by puzzle number
+}
+
+// Replaces the answer words and the words of other lengths with l
+func (d *dict) setWords(l *wordList) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.words = l.words
+	d.wordMap = l.wordMap
+	d.ranks = l.ranks
+	d.others = l.others
+	d.otherMap = l.otherMap
+	solverCache.reset()
+	rankCache.reset()
 }
 
 func (d *dict) size() int {
-	return len(d.words)
+	return len(d.answers())
+}
+
+// Returns the current answer words. The slice is never modified, so it can be
+// used without holding the lock.
+func (d *dict) answers() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.words
+}
+
+func (d *dict) isAnswer(w string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.wordMap[w]
+}
+
+func (d *dict) isGuess(w string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.guessMap[w]
+}
+
+func (d *dict) pinnedDaily(number int) (string, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	w, ok := d.daily[number]
+	return w, ok
+}
+
+// Pins w as the daily word of number, unless a word is already pinned, and
+// returns the pinned word
+func (d *dict) pinDaily(number int, w string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if pinned, ok := d.daily[number]; ok {
+		return pinned
+	}
+	d.daily[number] = w
+
+	return w
+}
+
+// Returns the current words of length n, which like answers can be used
+// without holding the lock
+func (d *dict) ofLength(n int) []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.others[n]
 }
 
 func (d *dict) reset() {
 	d.words = []string{}
 	d.wordMap = make(map[string]bool)
+	d.ranks = make(map[string]int)
+	d.guesses = []string{}
+	d.guessMap = make(map[string]bool)
+	d.others = make(map[int][]string)
+	d.otherMap = make(map[string]bool)
+	d.daily = make(map[int]string)
 	d.init_once.Reset()
 	d.initalized = false
+	solverCache.reset()
+	rankCache.reset()
 }
 
-var wordleDict = &dict{initalized: false, words: []string{}, wordMap: make(map[string]bool)}
+var wordleDict = &dict{
+	initalized: false,
+	words:      []string{},
+	wordMap:    make(map[string]bool),
+	ranks:      make(map[string]int),
+	guessMap:   make(map[string]bool),
+	others:     make(map[int][]string),
+	otherMap:   make(map[string]bool),
+	daily:      make(map[int]string),
+}
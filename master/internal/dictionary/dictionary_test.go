@@ -1,9 +1,14 @@
 package dictionary
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
+	"os"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"aluance.io/wordleserver/internal/config"
@@ -28,6 +33,39 @@ func TestGenerateWord(t *testing.T) {
 	assert.Equal(config.CONFIG_GAME_WORDLENGTH, len(word))
 }
 
+func TestGenerateWordSeeded(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	// The same seed always generates the same word
+	seen := map[string]bool{}
+	for _, seed := range []int64{0, 1, 42, -7, 1 << 40} {
+		w1, err := GenerateWordSeeded(seed)
+		require.NoError(err)
+		w2, err := GenerateWordSeeded(seed)
+		require.NoError(err)
+		assert.Equal(w1, w2, seed)
+		assert.True(wordleDict.wordMap[w1], w1)
+		seen[w1] = true
+	}
+	assert.Greater(len(seen), 1, "different seeds should generate different words")
+
+	// Seeded generation doesn't depend on the global source
+	w1, _ := GenerateWordSeeded(42)
+	rand.Seed(1)
+	w2, _ := GenerateWordSeeded(42)
+	assert.Equal(w1, w2)
+
+	wordleDict.reset()
+	wordleDict.initalized = true // an empty dictionary
+	defer wordleDict.reset()
+	_, err := GenerateWordSeeded(42)
+	assert.ErrorIs(err, ErrEmptyDictionary)
+}
+
 func TestIsWordValid(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -80,3 +118,392 @@ func TestInitialize(t *testing.T) {
 
 	// assert.Equal(wordleDict.words[rand.Intn(TEST_DICTIONARY_LENGTH)], "bless")
 }
+
+func TestInitializeEmbedded(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	defer wordleDict.reset()
+
+	// Run with no word lists on disk
+	wd, err := os.Getwd()
+	require.NoError(err)
+	require.NoError(os.Chdir(t.TempDir()))
+	defer os.Chdir(wd)
+
+	wordleDict.reset()
+	require.NoError(Initialize(""))
+	assert.True(wordleDict.initalized)
+	assert.Len(wordleDict.words, 4266)
+	assert.True(IsWordValid("happy"))
+
+	// Bundled lists are found by name even though they aren't on disk
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+	assert.Len(wordleDict.words, TEST_DICTIONARY_LENGTH)
+
+	// Files on disk override the bundled lists
+	require.NoError(os.MkdirAll("data", 0o755))
+	require.NoError(os.WriteFile(TEST_DICTIONARY_FILEPATH, []byte("alpha\nbravo\n"), 0o644))
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+	assert.Equal([]string{"alpha", "bravo"}, wordleDict.words)
+
+	wordleDict.reset()
+	assert.Error(Initialize("data/missing.txt"))
+	assert.False(wordleDict.initalized)
+}
+
+func TestInitializeFromReader(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	defer wordleDict.reset()
+
+	wordleDict.reset()
+	r := strings.NewReader("Blank\nanime\r\n  DRAWN \nanime\nshort-list\n\ncat\n")
+	require.NoError(InitializeFromReader(r))
+	assert.True(wordleDict.initalized)
+
+	// Words are lowercased and trimmed, and duplicates dropped
+	assert.Equal([]string{"blank", "anime", "drawn"}, wordleDict.words)
+	for _, w := range []string{"blank", "BLANK", "anime", "drawn"} {
+		assert.True(IsWordValid(w), w)
+	}
+	assert.False(IsWordValid("lives"))
+	assert.True(IsWordValid("cat"), "words of other lengths are kept")
+
+	for i := 0; i < 20; i++ {
+		word, err := GenerateWord()
+		require.NoError(err)
+		assert.Contains(wordleDict.words, word)
+	}
+
+	// Already initialized, so later readers are ignored
+	require.NoError(InitializeFromReader(strings.NewReader("lives\n")))
+	assert.False(IsWordValid("lives"))
+
+	// A failed read leaves the dictionary uninitialized
+	wordleDict.reset()
+	assert.Error(InitializeFromReader(iotest.ErrReader(errors.New("read failed"))))
+	assert.False(wordleDict.initalized)
+	assert.Empty(wordleDict.words)
+}
+
+func TestInitializeRunes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	defer wordleDict.reset()
+
+	// Word length counts letters, not bytes
+	wordleDict.reset()
+	require.NoError(InitializeFromReader(strings.NewReader("crème\nCAFÉS\ncafé\nrêver\n")))
+	assert.Equal([]string{"crème", "cafés", "rêver"}, wordleDict.words)
+	for _, w := range []string{"CRÈME", "cafés", "rêver"} {
+		assert.True(IsWordValid(w), w)
+	}
+	assert.True(IsWordValid("café"), "four letter words are kept as other lengths")
+	assert.Equal([]string{"café"}, wordleDict.others[4])
+
+	require.NoError(AddWord("Éclat"))
+	assert.True(IsWordValid("éclat"))
+	assert.ErrorIs(AddWord("éclats"), ErrWordLength)
+
+	neighbors, err := Neighbors("crême")
+	require.NoError(err)
+	assert.Equal([]string{"crème"}, neighbors)
+}
+
+func TestWords(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	words, err := Words()
+	assert.NoError(err)
+	assert.Equal(TEST_DICTIONARY_LENGTH, len(words))
+
+	// The returned slice is a copy
+	words[0] = "xxxxx"
+	assert.NotEqual("xxxxx", wordleDict.words[0])
+}
+
+func TestRank(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	// Ranks follow the order of the frequency-ordered file
+	for i, w := range wordleDict.words[:10] {
+		rank, err := Rank(w)
+		assert.NoError(err)
+		assert.Equal(i, rank, w)
+	}
+
+	rank, err := Rank(wordleDict.words[0])
+	assert.NoError(err)
+	upper, err := Rank(strings.ToUpper(wordleDict.words[0]))
+	assert.NoError(err)
+	assert.Equal(rank, upper)
+
+	_, err = Rank("xxxxx")
+	assert.ErrorIs(err, ErrUnknownWord)
+}
+
+func TestNeighbors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	neighbors, err := Neighbors("NIGHT")
+	assert.NoError(err)
+	assert.Contains(neighbors, "light")
+	assert.Contains(neighbors, "right")
+	assert.NotContains(neighbors, "night")
+	for _, n := range neighbors {
+		assert.Equal("ight", n[1:], n)
+	}
+}
+
+func TestFirstLetterDistribution(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	dist := FirstLetterDistribution()
+	sum := 0.0
+	for r, f := range dist {
+		assert.True(r >= 'a' && r <= 'z', string(r))
+		assert.Greater(f, 0.0, string(r))
+		sum += f
+	}
+	assert.InDelta(1.0, sum, 1e-9)
+
+	tests := []struct {
+		letter rune
+		count  int
+	}{
+		{letter: 's', count: 174},
+		{letter: 'c', count: 110},
+		{letter: 'b', count: 104},
+	}
+
+	for _, test := range tests {
+		assert.InDelta(float64(test.count)/TEST_DICTIONARY_LENGTH, dist[test.letter], 1e-9, string(test.letter))
+	}
+
+	// An accented word counts under its first letter, not its first byte
+	require.NoError(AddWord("éclat"))
+	defer RemoveWord("éclat")
+	dist = FirstLetterDistribution()
+	assert.InDelta(1.0/(TEST_DICTIONARY_LENGTH+1), dist['é'], 1e-9)
+}
+
+func TestGuessOnlyWords(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+	assert.Empty(GuessOnlyWords())
+
+	require.NoError(InitializeGuesses(config.CONFIG_DICTIONARY_FILEPATH))
+	assert.Error(InitializeGuesses("data/missing.txt"))
+	defer wordleDict.reset()
+
+	answers, err := Words()
+	require.NoError(err)
+	isAnswer := map[string]bool{}
+	for _, w := range answers {
+		isAnswer[w] = true
+	}
+
+	// The guess-only words are the guess list less the answer list
+	words := GuessOnlyWords()
+	assert.Len(words, 3089)
+	for _, w := range words {
+		assert.False(isAnswer[w], w)
+		assert.True(IsWordValid(w), w)
+	}
+	assert.Contains(words, "aback")
+	assert.NotContains(words, "happy")
+
+	// Guess-only words are valid but never generated
+	assert.True(IsWordValid("ABACK"))
+	for i := 0; i < 1000; i++ {
+		w, err := GenerateWord()
+		require.NoError(err)
+		assert.True(isAnswer[w], w)
+
+		w, err = GenerateWordOfLength(config.CONFIG_GAME_WORDLENGTH)
+		require.NoError(err)
+		assert.True(isAnswer[w], w)
+	}
+	fives, err := WordsOfLength(config.CONFIG_GAME_WORDLENGTH)
+	require.NoError(err)
+	assert.NotContains(fives, "aback")
+
+	// Replacing the guess list drops the earlier guesses
+	require.NoError(InitializeGuesses(TEST_DICTIONARY_FILEPATH))
+	assert.False(IsWordValid("aback"))
+	assert.Empty(GuessOnlyWords())
+}
+
+func TestWordsOfLength(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	words, err := WordsOfLength(config.CONFIG_GAME_WORDLENGTH)
+	require.NoError(err)
+	assert.Len(words, TEST_DICTIONARY_LENGTH)
+
+	words, err = WordsOfLength(6)
+	require.NoError(err)
+	assert.Len(words, 1488)
+	for _, w := range words {
+		assert.Len(w, 6)
+		assert.True(IsWordValid(w), w)
+	}
+
+	// Other lengths can be generated but are never used by the solver
+	w, err := GenerateWordOfLength(6)
+	require.NoError(err)
+	assert.Contains(words, w)
+	_, err = Par(w, "arise")
+	assert.ErrorIs(err, ErrUnknownWord)
+
+	_, err = GenerateWordOfLength(3)
+	assert.ErrorIs(err, ErrEmptyDictionary)
+	words, err = WordsOfLength(3)
+	assert.NoError(err)
+	assert.Empty(words)
+}
+
+func TestAddRemoveWord(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+	defer wordleDict.reset()
+
+	// Added words are valid immediately, and adding again has no effect
+	assert.False(IsWordValid("qophs"))
+	require.NoError(AddWord("QOPHS"))
+	assert.True(IsWordValid("qophs"))
+	require.NoError(AddWord("qophs"))
+	assert.Len(wordleDict.words, TEST_DICTIONARY_LENGTH+1)
+	rank, err := Rank("qophs")
+	require.NoError(err)
+	assert.Equal(TEST_DICTIONARY_LENGTH, rank)
+
+	assert.ErrorIs(AddWord("four"), ErrWordLength)
+	assert.ErrorIs(AddWord("sixsix"), ErrWordLength)
+
+	// A removed answer is no longer valid or generated
+	words, err := Words()
+	require.NoError(err)
+	for _, w := range words[1:] {
+		require.NoError(RemoveWord(w))
+	}
+	assert.False(IsWordValid(words[1]))
+	assert.ErrorIs(RemoveWord(words[1]), ErrUnknownWord)
+	assert.Equal([]string{words[0]}, wordleDict.words)
+	rank, err = Rank(words[0])
+	require.NoError(err)
+	assert.Zero(rank)
+	for i := 0; i < 20; i++ {
+		w, err := GenerateWord()
+		require.NoError(err)
+		assert.Equal(words[0], w)
+	}
+
+	// Changes are safe alongside readers
+	var wg sync.WaitGroup
+	for _, w := range []string{"alpha", "bravo", "delta", "gamma"} {
+		wg.Add(2)
+		go func(w string) {
+			defer wg.Done()
+			assert.NoError(AddWord(w))
+			assert.NoError(RemoveWord(w))
+		}(w)
+		go func() {
+			defer wg.Done()
+			_, err := GenerateWord()
+			assert.NoError(err)
+			IsWordValid("alpha")
+		}()
+	}
+	wg.Wait()
+	assert.Len(wordleDict.words, 1)
+}
+
+func TestReload(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+	defer wordleDict.reset()
+
+	dir := t.TempDir()
+	seasonal := dir + "/seasonal.txt"
+	require.NoError(os.WriteFile(seasonal, []byte("frost\nSLEDS\nholly\nfrost\nsnow\n"), 0o644))
+	empty := dir + "/empty.txt"
+	require.NoError(os.WriteFile(empty, []byte("snow\n"), 0o644))
+
+	require.NoError(Reload(seasonal))
+	assert.Equal([]string{"frost", "sleds", "holly"}, wordleDict.words)
+	assert.True(IsWordValid("sleds"))
+	assert.False(IsWordValid("bless"))
+	assert.True(IsWordValid("snow"))
+	rank, err := Rank("holly")
+	require.NoError(err)
+	assert.Equal(2, rank)
+
+	// A list that can't be used leaves the dictionary unchanged
+	assert.Error(Reload(dir + "/missing.txt"))
+	assert.ErrorIs(Reload(empty), ErrEmptyDictionary)
+	assert.Len(wordleDict.words, 3)
+
+	// Readers never see a partly reloaded list
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// Both lists have frost, while only one has bless
+				assert.True(IsWordValid("frost"))
+				IsWordValid("bless")
+				w, err := GenerateWord()
+				assert.NoError(err)
+				assert.Len(w, config.CONFIG_GAME_WORDLENGTH)
+			}
+		}()
+	}
+	both := dir + "/both.txt"
+	require.NoError(os.WriteFile(both, []byte("bless\nfrost\n"), 0o644))
+	for i := 0; i < 50; i++ {
+		assert.NoError(Reload(both))
+		assert.NoError(Reload(seasonal))
+	}
+	close(done)
+	wg.Wait()
+	assert.Equal([]string{"frost", "sleds", "holly"}, wordleDict.words)
+}
@@ -0,0 +1,11 @@
+package dictionary
+
+import "errors"
+
+var (
+	ErrEmptyDictionary = errors.New("dictionary is empty")
+	ErrBeforeEpoch     = errors.New("date is before the daily epoch")
+	ErrUnknownWord     = errors.New("word is not in dictionary")
+	ErrSetSize         = errors.New("invalid number of words requested")
+	ErrWordLength      = errors.New("word has the wrong length")
+)
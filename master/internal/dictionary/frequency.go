@@ -0,0 +1,79 @@
+package dictionary
+
+import (
+	"bufio"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"aluance.io/wordleserver/internal/config"
+)
+
+// Returns how rare w is, from 0 for the most common word of its length to 1
+// for the rarest, by its position in the frequency-ordered list
+// config.CONFIG_FREQUENCY_FILEPATH. Words not in that list are the rarest.
+// Unlike Rank, this doesn't depend on the order of the dictionary file, which
+// may be alphabetical.
+func Rarity(w string) (float64, error) {
+	f, err := loadFrequencies()
+	if err != nil {
+		return 0, err
+	}
+
+	w = strings.ToLower(w)
+	rank, ok := f.ranks[w]
+	if !ok {
+		return 1, nil
+	}
+	n := f.counts[utf8.RuneCountInString(w)]
+	if n < 2 {
+		return 0, nil
+	}
+
+	return float64(rank) / float64(n-1), nil
+}
+
+/////////////////
+
+// Ranks of the words of the frequency list, among words of the same length
+type frequencies struct {
+	ranks  map[string]int
+	counts map[int]int // words of each length
+}
+
+var freq struct {
+	once sync.Once
+	f    *frequencies
+	err  error
+}
+
+// Loads the frequency list the first time it is needed
+func loadFrequencies() (*frequencies, error) {
+	freq.once.Do(func() {
+		r, err := openWordList(config.CONFIG_FREQUENCY_FILEPATH)
+		if err != nil {
+			freq.err = err
+			return
+		}
+		defer r.Close()
+
+		f := &frequencies{ranks: make(map[string]int), counts: make(map[int]int)}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+			n := utf8.RuneCountInString(word)
+			if n < 1 {
+				continue
+			}
+			if _, ok := f.ranks[word]; !ok {
+				f.ranks[word] = f.counts[n]
+				f.counts[n]++
+			}
+		}
+		if freq.err = scanner.Err(); freq.err == nil {
+			freq.f = f
+		}
+	})
+
+	return freq.f, freq.err
+}
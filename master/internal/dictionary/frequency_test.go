@@ -0,0 +1,41 @@
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRarity(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		word   string
+		rarity float64
+		delta  float64
+	}{
+		{word: "about", rarity: 0},
+		{word: "World", rarity: 12.0 / 1366, delta: 1e-9},
+		{word: "young", rarity: 108.0 / 1366, delta: 1e-9},
+		{word: "puppy", rarity: 0.84, delta: 0.01},
+		{word: "abets", rarity: 1}, // not a common word
+		{word: "xxxxx", rarity: 1},
+	}
+
+	for _, test := range tests {
+		rarity, err := Rarity(test.word)
+		assert.NoError(err, test.word)
+		assert.InDelta(test.rarity, rarity, test.delta, test.word)
+	}
+
+	// Rarity doesn't depend on the dictionary, which may be alphabetical
+	wordleDict.reset()
+	defer wordleDict.reset()
+	assert.NoError(Initialize(""))
+	rank, err := Rank("world")
+	assert.NoError(err)
+	rarity, err := Rarity("world")
+	assert.NoError(err)
+	assert.Greater(rank, 4000)
+	assert.Less(rarity, 0.01)
+}
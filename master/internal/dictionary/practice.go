@@ -0,0 +1,46 @@
+package dictionary
+
+import "sort"
+
+// Returns n practice words whose par for opener is spread evenly from the
+// easiest to the hardest words in the dictionary. Within each par, more
+// common words by Rarity are picked first. The set is ordered from easy to hard.
+func BalancedSet(n int, opener string) ([]string, error) {
+	pars, err := parTable(opener)
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 || n > len(pars) {
+		return nil, ErrSetSize
+	}
+
+	// Group the words by par, most common first within each group
+	words, err := rankedAnswers()
+	if err != nil {
+		return nil, err
+	}
+	levels := map[int][]string{}
+	for _, w := range words {
+		levels[pars[w]] = append(levels[pars[w]], w)
+	}
+	keys := []int{}
+	for k := range levels {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	// Take one word from each par in turn until the set is full
+	set := make([]string, 0, n)
+	for i := 0; len(set) < n; i++ {
+		for _, k := range keys {
+			if i < len(levels[k]) && len(set) < n {
+				set = append(set, levels[k][i])
+			}
+		}
+	}
+
+	sort.SliceStable(set, func(i, j int) bool {
+		return pars[set[i]] < pars[set[j]]
+	})
+	return set, nil
+}
@@ -0,0 +1,106 @@
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalancedSet(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	pars, err := parTable("arise")
+	require.NoError(err)
+	assert.Len(pars, TEST_DICTIONARY_LENGTH)
+	easiest, hardest := TEST_DICTIONARY_LENGTH, 0
+	for _, p := range pars {
+		if p < easiest {
+			easiest = p
+		}
+		if p > hardest {
+			hardest = p
+		}
+	}
+	require.Less(easiest, hardest)
+
+	// The table agrees with solving each word on its own
+	for _, w := range []string{"arise", "happy", "world", "about"} {
+		par, err := Par(w, "arise")
+		require.NoError(err)
+		assert.Equal(par, pars[w], w)
+	}
+
+	tests := []struct {
+		n   int
+		err error
+	}{
+		{n: 1},
+		{n: 10},
+		{n: 25},
+		{n: 0, err: ErrSetSize},
+		{n: TEST_DICTIONARY_LENGTH + 1, err: ErrSetSize},
+	}
+
+	for _, test := range tests {
+		set, err := BalancedSet(test.n, "arise")
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err)
+		require.Len(set, test.n)
+
+		seen := map[string]bool{}
+		last := 0
+		for _, w := range set {
+			assert.False(seen[w], w)
+			seen[w] = true
+			assert.GreaterOrEqual(pars[w], last, w)
+			last = pars[w]
+		}
+		if test.n >= hardest-easiest+1 {
+			assert.Equal(easiest, pars[set[0]])
+			assert.Equal(hardest, pars[set[len(set)-1]])
+		}
+	}
+
+	_, err = BalancedSet(10, "xxxxx")
+	assert.ErrorIs(err, ErrUnknownWord)
+}
+
+func TestBalancedSetCommonFirst(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The default dictionary is alphabetical, so commonness comes from the
+	// frequency list
+	wordleDict.reset()
+	require.NoError(Initialize(""))
+
+	pars, err := parTable("arise")
+	require.NoError(err)
+	levels := map[int]bool{}
+	for _, p := range pars {
+		levels[p] = true
+	}
+
+	// With one word per par, each is the most common word of its par
+	set, err := BalancedSet(len(levels), "arise")
+	require.NoError(err)
+	for _, w := range set {
+		r, err := Rarity(w)
+		require.NoError(err)
+		for other, p := range pars {
+			if p == pars[w] {
+				ro, err := Rarity(other)
+				require.NoError(err)
+				assert.LessOrEqual(r, ro, "%s over %s", w, other)
+			}
+		}
+	}
+}
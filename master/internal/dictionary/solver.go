@@ -0,0 +1,247 @@
+package dictionary
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"aluance.io/wordleserver/internal/config"
+)
+
+// Pattern characters used for encoded hints
+const (
+	PatternGreen  = 'G'
+	PatternYellow = 'Y'
+	PatternGrey   = '-'
+)
+
+// Returns the number of guesses the solver needs to reach word when it
+// always opens with opener. Results are cached per opener and word.
+func Par(word string, opener string) (int, error) {
+	guesses, err := solve(word, opener)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(guesses), nil
+}
+
+// Returns the guesses the solver makes to reach word when it always opens
+// with opener. The last guess is word itself.
+func Solve(word string, opener string) ([]string, error) {
+	guesses, err := solve(word, opener)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{}, guesses...), nil
+}
+
+// Returns a worked example of solving secret: the solver's guesses from
+// opener, ending with secret. An empty opener uses the configured opener.
+func ExampleSolve(secret string, opener string) ([]string, error) {
+	if len(opener) < 1 {
+		opener = config.CONFIG_SOLVER_OPENER
+	}
+
+	return Solve(secret, opener)
+}
+
+// Returns the distinct patterns guess produces against every answer word,
+// sorted. Patterns use the same encoding as game.EncodeHints.
+func PossiblePatterns(guess string) []string {
+	patterns := []string{}
+	if err := Initialize(""); err != nil {
+		return patterns
+	}
+
+	guess = strings.ToLower(guess)
+	seen := map[string]bool{}
+	for _, w := range wordleDict.answers() {
+		if p := pattern(w, guess); !seen[p] {
+			seen[p] = true
+			patterns = append(patterns, p)
+		}
+	}
+	sort.Strings(patterns)
+
+	return patterns
+}
+
+// Returns the pattern of hints guess would receive against secret. Neither
+// word needs to be in the dictionary.
+func Pattern(secret string, guess string) string {
+	return pattern(strings.ToLower(secret), strings.ToLower(guess))
+}
+
+// Encodes the hints guess would receive against secret, using the official
+// two-pass rules: greens first, then yellows while unmatched letters remain.
+// Games score guesses the same way; a test in package game checks that the
+// two agree.
+func pattern(secret string, guess string) string {
+	s, g := []rune(secret), []rune(guess)
+	p := []byte(strings.Repeat(string(PatternGrey), len(g)))
+	remaining := map[rune]int{}
+
+	for i := 0; i < len(g); i++ {
+		if i < len(s) && s[i] == g[i] {
+			p[i] = PatternGreen
+		} else if i < len(s) {
+			remaining[s[i]]++
+		}
+	}
+	for i := 0; i < len(g); i++ {
+		if p[i] != PatternGreen && remaining[g[i]] > 0 {
+			p[i] = PatternYellow
+			remaining[g[i]]--
+		}
+	}
+
+	return string(p)
+}
+
+func isSolvedPattern(p string) bool {
+	return len(p) > 0 && strings.Count(p, string(PatternGreen)) == len(p)
+}
+
+// Runs the solver against word starting from opener. Returns the guesses made,
+// ending with word itself.
+func solve(word string, opener string) ([]string, error) {
+	if err := Initialize(""); err != nil {
+		return nil, err
+	}
+
+	word = strings.ToLower(word)
+	opener = strings.ToLower(opener)
+	if !wordleDict.isAnswer(word) || !isOpener(opener) {
+		return nil, ErrUnknownWord
+	}
+
+	key := opener + ":" + word
+	if guesses, ok := solverCache.get(key); ok {
+		return guesses, nil
+	}
+
+	guesses := []string{}
+	cands := wordleDict.answers()
+	for guess := opener; ; guess = bestGuess(cands) {
+		guesses = append(guesses, guess)
+
+		p := pattern(word, guess)
+		if isSolvedPattern(p) {
+			break
+		}
+		cands = filterCandidates(cands, guess, p)
+		if len(cands) < 1 {
+			return nil, ErrUnknownWord
+		}
+	}
+
+	solverCache.set(key, guesses)
+	return guesses, nil
+}
+
+// Returns the par of every dictionary word for opener, by walking the
+// solver's decision tree once instead of solving each word separately.
+func parTable(opener string) (map[string]int, error) {
+	if err := Initialize(""); err != nil {
+		return nil, err
+	}
+
+	opener = strings.ToLower(opener)
+	if !isOpener(opener) {
+		return nil, ErrUnknownWord
+	}
+
+	words := wordleDict.answers()
+	pars := make(map[string]int, len(words))
+	walkSolver(words, opener, 1, pars)
+	return pars, nil
+}
+
+func walkSolver(cands []string, guess string, depth int, pars map[string]int) {
+	groups := map[string][]string{}
+	order := []string{}
+	for _, c := range cands {
+		p := pattern(c, guess)
+		if isSolvedPattern(p) {
+			pars[c] = depth
+			continue
+		}
+		if _, ok := groups[p]; !ok {
+			order = append(order, p)
+		}
+		groups[p] = append(groups[p], c)
+	}
+
+	for _, p := range order {
+		walkSolver(groups[p], bestGuess(groups[p]), depth+1, pars)
+	}
+}
+
+// Returns the words that would have produced pattern p for guess
+func filterCandidates(words []string, guess string, p string) []string {
+	cands := []string{}
+	for _, w := range words {
+		if pattern(w, guess) == p {
+			cands = append(cands, w)
+		}
+	}
+
+	return cands
+}
+
+// Picks the candidate that minimizes the largest group of candidates left
+// after guessing it. Ties go to the earliest word in dictionary order.
+func bestGuess(cands []string) string {
+	best, bestSize := "", len(cands)+1
+	for _, guess := range cands {
+		groups := map[string]int{}
+		largest := 0
+		for _, c := range cands {
+			p := pattern(c, guess)
+			if groups[p]++; groups[p] > largest {
+				largest = groups[p]
+			}
+		}
+		if largest < bestSize {
+			best, bestSize = guess, largest
+		}
+	}
+
+	return best
+}
+
+type cache struct {
+	mu      sync.Mutex
+	entries map[string][]string
+}
+
+func (c *cache) get(key string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.entries[key]
+	return v, ok
+}
+
+func (c *cache) set(key string, v []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = v
+}
+
+func (c *cache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string][]string)
+}
+
+var solverCache = &cache{entries: make(map[string][]string)}
+
+// Openers may be any valid guess of the configured length
+func isOpener(w string) bool {
+	return wordleDict.isAnswer(w) || wordleDict.isGuess(w)
+}
@@ -0,0 +1,173 @@
+package dictionary
+
+import (
+	"testing"
+
+	"aluance.io/wordleserver/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPattern(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		secret string
+		guess  string
+		result string
+	}{
+		{secret: "happy", guess: "happy", result: "GGGGG"},
+		{secret: "happy", guess: "seven", result: "-----"},
+		{secret: "happy", guess: "heave", result: "G-Y--"},
+		{secret: "happy", guess: "paint", result: "YG---"},
+		{secret: "allot", guess: "lolly", result: "YYG--"},
+		{secret: "abbey", guess: "kayak", result: "-YY--"},
+
+		// Accented letters are single letters, distinct from unaccented ones
+		{secret: "rêver", guess: "crème", result: "-Y--Y"},
+		{secret: "fêtée", guess: "éclat", result: "Y---Y"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.result, pattern(test.secret, test.guess), test.secret+"/"+test.guess)
+	}
+}
+
+func TestPar(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	tests := []struct {
+		word   string
+		opener string
+		result int
+		err    error
+	}{
+		{word: "arise", opener: "arise", result: 1},
+		{word: "happy", opener: "arise"},
+		{word: "world", opener: "arise"},
+		{word: "about", opener: "raise"},
+		{word: "xxxxx", opener: "arise", err: ErrUnknownWord},
+		{word: "happy", opener: "xxxxx", err: ErrUnknownWord},
+	}
+
+	for _, test := range tests {
+		par, err := Par(test.word, test.opener)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err, test.word)
+		if test.result > 0 {
+			assert.Equal(test.result, par)
+		}
+		assert.GreaterOrEqual(par, 1)
+		assert.LessOrEqual(par, config.CONFIG_GAME_MAXVALIDATTEMPTS, test.word)
+
+		// Par is stable, both from the cache and from a fresh computation
+		again, err := Par(test.word, test.opener)
+		assert.NoError(err)
+		assert.Equal(par, again)
+
+		solverCache.reset()
+		again, err = Par(test.word, test.opener)
+		assert.NoError(err)
+		assert.Equal(par, again)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	line, err := Solve("happy", "arise")
+	require.NoError(err)
+	assert.Equal("arise", line[0])
+	assert.Equal("happy", line[len(line)-1])
+
+	par, err := Par("happy", "arise")
+	assert.NoError(err)
+	assert.Len(line, par)
+
+	// The returned line is a copy of the cached one
+	line[0] = "xxxxx"
+	again, err := Solve("happy", "arise")
+	assert.NoError(err)
+	assert.Equal("arise", again[0])
+}
+
+func TestExampleSolve(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	tests := []struct {
+		secret string
+		opener string
+		first  string
+		err    error
+	}{
+		{secret: "happy", opener: "raise", first: "raise"},
+		{secret: "world", opener: "", first: config.CONFIG_SOLVER_OPENER},
+		{secret: "about", opener: "ABOUT", first: "about"},
+		{secret: "xxxxx", opener: "raise", err: ErrUnknownWord},
+	}
+
+	for _, test := range tests {
+		steps, err := ExampleSolve(test.secret, test.opener)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err, test.secret)
+		require.NotEmpty(steps)
+		assert.Equal(test.first, steps[0])
+		assert.Equal(test.secret, steps[len(steps)-1])
+		for _, s := range steps {
+			assert.True(IsWordValid(s), s)
+		}
+	}
+}
+
+func TestPossiblePatterns(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// A small dictionary, so the patterns can be listed by hand
+	wordleDict.reset()
+	defer wordleDict.reset()
+	wordleDict.words = []string{"happy", "heave", "paint", "seven", "puppy"}
+	for _, w := range wordleDict.words {
+		wordleDict.wordMap[w] = true
+	}
+	wordleDict.initalized = true
+
+	tests := []struct {
+		guess  string
+		result []string
+	}{
+		{guess: "happy", result: []string{"-----", "--GGG", "-GY--", "GGGGG", "GY---"}},
+		{guess: "bless", result: []string{"-----", "--Y--", "--YY-"}}, // never all green
+		{guess: "HEAVE", result: []string{"-----", "--Y--", "-G-YY", "G-Y--", "GGGGG"}},
+	}
+
+	for _, test := range tests {
+		patterns := PossiblePatterns(test.guess)
+		assert.Equal(test.result, patterns, test.guess)
+	}
+
+	// Over the full dictionary, a word produces both extremes
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+	patterns := PossiblePatterns("happy")
+	assert.Contains(patterns, "GGGGG")
+	assert.Contains(patterns, "-----")
+}
@@ -0,0 +1,83 @@
+package dictionary
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Weighs a word by its frequency rank (0 is most common) for weighted word
+// generation. Weights must not be negative.
+type Weighting func(rank int) float64
+
+// Weighs each word by the inverse of its rank, so the most common words are
+// the most likely
+func InverseRank(rank int) float64 {
+	return 1 / float64(rank+1)
+}
+
+// Weighs every word equally, the same as GenerateWord
+func Uniform(rank int) float64 {
+	return 1
+}
+
+// Generates a word chosen with a probability proportional to its weight. Words
+// are ranked by Rarity, not by their order in the dictionary file, so the
+// weighting holds for alphabetical dictionaries too. A nil weight uses
+// InverseRank.
+func GenerateWordWeighted(weight Weighting) (string, error) {
+	if err := Initialize(""); err != nil {
+		return "", err
+	}
+	if weight == nil {
+		weight = InverseRank
+	}
+
+	words, err := rankedAnswers()
+	if err != nil {
+		return "", err
+	}
+	cumulative := make([]float64, len(words))
+	total := 0.0
+	for i := range words {
+		if w := weight(i); w > 0 {
+			total += w
+		}
+		cumulative[i] = total
+	}
+	if total <= 0 {
+		return "", ErrEmptyDictionary
+	}
+
+	r := rand.Float64() * total
+	i := sort.Search(len(cumulative), func(i int) bool { return cumulative[i] > r })
+	if i == len(words) {
+		i-- // guards against rounding at the top of the range
+	}
+
+	return words[i], nil
+}
+
+// Returns the answers from most to least common by Rarity. Equally rare words
+// keep their dictionary order.
+func rankedAnswers() ([]string, error) {
+	if words, ok := rankCache.get(""); ok {
+		return words, nil
+	}
+
+	words := append([]string(nil), wordleDict.answers()...)
+	rarity := make(map[string]float64, len(words))
+	for _, w := range words {
+		r, err := Rarity(w)
+		if err != nil {
+			return nil, err
+		}
+		rarity[w] = r
+	}
+	sort.SliceStable(words, func(i, j int) bool { return rarity[words[i]] < rarity[words[j]] })
+
+	rankCache.set("", words)
+	return words, nil
+}
+
+// Caches rankedAnswers until the answers change
+var rankCache = &cache{entries: make(map[string][]string)}
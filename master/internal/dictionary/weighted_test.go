@@ -0,0 +1,102 @@
+package dictionary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateWordWeighted(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wordleDict.reset()
+	require.NoError(Initialize(TEST_DICTIONARY_FILEPATH))
+
+	const draws = 20000
+	decile := TEST_DICTIONARY_LENGTH / 10
+
+	tests := []struct {
+		name     string
+		weight   Weighting
+		weighted bool
+	}{
+		{name: "default", weight: nil, weighted: true},
+		{name: "inverse rank", weight: InverseRank, weighted: true},
+		{name: "uniform", weight: Uniform, weighted: false},
+	}
+
+	for _, test := range tests {
+		top, bottom := 0, 0
+		for i := 0; i < draws; i++ {
+			w, err := GenerateWordWeighted(test.weight)
+			require.NoError(err, test.name)
+			rank, err := Rank(w)
+			require.NoError(err, test.name)
+
+			if rank < decile {
+				top++
+			} else if rank >= TEST_DICTIONARY_LENGTH-decile {
+				bottom++
+			}
+		}
+
+		if test.weighted {
+			// Over many draws the most common words dominate
+			assert.Greater(top, 10*bottom, test.name)
+		} else {
+			// Each decile expects a tenth of the draws
+			assert.InDelta(draws/10, top, draws/25, test.name)
+			assert.InDelta(draws/10, bottom, draws/25, test.name)
+		}
+	}
+
+	// Only words with positive weight are generated
+	first := func(rank int) float64 {
+		if rank == 0 {
+			return 1
+		}
+		return 0
+	}
+	for i := 0; i < 100; i++ {
+		w, err := GenerateWordWeighted(first)
+		require.NoError(err)
+		assert.Equal(wordleDict.words[0], w)
+	}
+
+	_, err := GenerateWordWeighted(func(int) float64 { return 0 })
+	assert.ErrorIs(err, ErrEmptyDictionary)
+}
+
+func TestGenerateWordWeightedDefault(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The default dictionary is alphabetical, so it is ranked by the
+	// frequency list rather than by its order
+	wordleDict.reset()
+	require.NoError(Initialize(""))
+
+	const draws = 5000
+	listed, early := 0, 0
+	for i := 0; i < draws; i++ {
+		w, err := GenerateWordWeighted(nil)
+		require.NoError(err)
+		r, err := Rarity(w)
+		require.NoError(err)
+
+		if r < 1 {
+			listed++
+		}
+		if strings.HasPrefix(w, "a") {
+			early++
+		}
+	}
+
+	// Most draws are words in the frequency list, which are about a quarter
+	// of the dictionary, and the alphabetically first words aren't favoured
+	assert.Greater(listed, draws*7/10)
+	assert.Less(early, draws/5)
+}
@@ -0,0 +1,50 @@
+package game
+
+import "sort"
+
+// Factory used to create an adversarial game, where the secret word isn't
+// fixed: each guess is scored with the hints that keep the most candidates,
+// so the game only commits to a secret once a single candidate is left.
+func CreateAdversarial(opts GameOptions) (Game, error) {
+	opts.Adversarial = true
+	return CreateWithOptions("", opts)
+}
+
+// Chooses the secret of an adversarial game for guess from the remaining
+// candidates. The candidates are grouped by the hints guess would receive,
+// and the secret is taken from the largest group. Ties go to a group that
+// isn't a win, then to the group whose hints encode first, so play is
+// reproducible.
+func (g wordleGame) adversarialSecret(guess string, cands []string) (string, error) {
+	if len(cands) < 1 {
+		return g.SecretWord, nil
+	}
+
+	groups := map[string][]string{}
+	score := make([]LetterHint, g.wordLength())
+	for _, c := range cands {
+		probe := wordleGame{SecretWord: c}
+		if err := probe.scoreWord(guess, &score); err != nil {
+			return "", err
+		}
+		key := EncodeHints(score)
+		groups[key] = append(groups[key], c)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := groups[keys[i]], groups[keys[j]]
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		if wa, wb := a[0] == guess, b[0] == guess; wa != wb {
+			return wb
+		}
+		return keys[i] < keys[j]
+	})
+
+	return groups[keys[0]][0], nil
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdversarialGame(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	count := func(s string) int {
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		return int(out["candidateCount"].(float64))
+	}
+
+	game, err := CreateAdversarial(GameOptions{MaxAttempts: 12})
+	require.NoError(err)
+	v := game.(*wordleGame)
+	s, err := game.Describe()
+	require.NoError(err)
+	last := count(s)
+	assert.Greater(last, 1000)
+	assert.NotContains(s, "secretWord")
+
+	// Each guess keeps the largest group of candidates, so the count never
+	// grows. Guessing a candidate while others remain never wins.
+	for _, w := range []string{"arise", "clout", "nymph"} {
+		partition, err := game.GuessPartition(w)
+		require.NoError(err)
+		largest := 0
+		for _, n := range partition {
+			if n > largest {
+				largest = n
+			}
+		}
+
+		s, err := game.Play(w)
+		require.NoError(err, w)
+		n := count(s)
+		assert.Equal(largest, n, w)
+		assert.LessOrEqual(n, last, w)
+		last = n
+	}
+
+	for game.Status() == InPlay {
+		cands, err := v.candidates()
+		require.NoError(err)
+		require.NotEmpty(cands)
+		if len(cands) == 1 {
+			// Hints are given once the secret is forced
+			s, err := game.Hint()
+			require.NoError(err)
+			var reveal LetterReveal
+			require.NoError(json.Unmarshal([]byte(s), &reveal))
+			assert.Equal(string(cands[0][reveal.Position]), reveal.Letter)
+		}
+		s, err := game.Play(cands[0])
+		require.NoError(err, cands[0])
+		if len(cands) > 1 {
+			assert.Equal(InPlay, game.Status(), "a win is only forced by the last candidate")
+			n := count(s)
+			assert.Less(n, last)
+			last = n
+			continue
+		}
+		assert.Equal(Won, game.Status(), "the last candidate must win")
+		assert.Equal(1, count(s))
+		assert.Equal(cands[0], v.SecretWord)
+	}
+	assert.Equal(Won, game.Status())
+
+	_, err = game.Hint()
+	assert.ErrorIs(err, ErrGameOver)
+	game, err = CreateAdversarial(GameOptions{})
+	require.NoError(err)
+	_, err = game.Hint()
+	assert.ErrorIs(err, ErrAdversarialHint)
+}
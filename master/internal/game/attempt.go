@@ -3,7 +3,10 @@ package game
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"time"
+
+	"aluance.io/wordleserver/internal/dictionary"
 )
 
 // Enum for letter hints returned after each attempt
@@ -18,10 +21,26 @@ const (
 )
 
 type WordleAttempt struct {
-	TryWord     string       `json:"tryWord"`
+	TryWord     string       `json:"tryWord"` // as typed; see word for the scored form
 	IsValidWord bool         `json:"isValidWord"`
 	TryResult   []LetterHint `json:"tryResult"`
 	TimeStamp   time.Time    `json:"timeStamp"`
+	Eliminated  int          `json:"eliminated"` // candidate words ruled out by this attempt
+}
+
+// Returns the guess normalized to upper case, as it was scored. Guesses are
+// kept as typed so reports can echo them.
+func (a WordleAttempt) word() string {
+	return strings.ToUpper(a.TryWord)
+}
+
+// A read-only view of one attempt, returned by Game.GetAttempts
+type AttemptView struct {
+	Index       int          `json:"index"` // position of the attempt, from 0
+	TryWord     string       `json:"tryWord"`
+	Hints       []LetterHint `json:"hints"`
+	IsValidWord bool         `json:"isValidWord"`
+	IsWinning   bool         `json:"isWinning"` // the attempt that won the game
 }
 
 var mapLetterHintToString = map[LetterHint]string{
@@ -40,6 +59,29 @@ var mapStringToLetterHint = map[string]LetterHint{
 	"Red":    Red,
 }
 
+// Single character codes of each hint, matching the dictionary pattern encoding
+var mapLetterHintToCode = map[LetterHint]byte{
+	Blank:  '.',
+	Green:  dictionary.PatternGreen,
+	Yellow: dictionary.PatternYellow,
+	Grey:   dictionary.PatternGrey,
+	Red:    'R',
+}
+
+// Encodes hints as a compact string, e.g. "G-Y--"
+func EncodeHints(hints []LetterHint) string {
+	b := make([]byte, len(hints))
+	for i, h := range hints {
+		if c, ok := mapLetterHintToCode[h]; ok {
+			b[i] = c
+		} else {
+			b[i] = '?'
+		}
+	}
+
+	return string(b)
+}
+
 func (h LetterHint) String() string {
 	if s, ok := mapLetterHintToString[h]; ok {
 		return s
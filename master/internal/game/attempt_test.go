@@ -59,3 +59,20 @@ func TestUnMarshalJSON(t *testing.T) {
 		assert.Equal(test.result, lh)
 	}
 }
+
+func TestEncodeHints(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		hints  []LetterHint
+		result string
+	}{
+		{hints: []LetterHint{}, result: ""},
+		{hints: []LetterHint{Green, Grey, Yellow, Grey, Grey}, result: "G-Y--"},
+		{hints: []LetterHint{Blank, Red, 100}, result: ".R?"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.result, EncodeHints(test.hints))
+	}
+}
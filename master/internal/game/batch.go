@@ -0,0 +1,85 @@
+package game
+
+import (
+	"sync"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/dictionary"
+)
+
+// A guess to play in a game, identified by its ID
+type BatchPlay struct {
+	ID   string `json:"id"`
+	Word string `json:"word"`
+}
+
+// The outcome of one play in a batch. Result is the game status report, empty
+// if the game could not be loaded.
+type BatchResult struct {
+	ID     string `json:"id"`
+	Result string `json:"result"`
+	Err    error  `json:"-"`
+}
+
+// Plays each guess in its game concurrently, using at most
+// CONFIG_GAME_BATCHWORKERS workers. Results are in the order of plays; a
+// failed play is reported in its result and doesn't stop the others. Each
+// game is updated atomically, so plays on the same game are not lost.
+func PlayBatch(plays []BatchPlay) ([]BatchResult, error) {
+	results := make([]BatchResult, len(plays))
+	sem := make(chan struct{}, config.CONFIG_GAME_BATCHWORKERS)
+	var wg sync.WaitGroup
+	for i, p := range plays {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, p BatchPlay) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = playOne(p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func playOne(p BatchPlay) BatchResult {
+	r := BatchResult{ID: p.ID}
+
+	game, err := Retrieve(p.ID)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	r.Result, r.Err = game.Play(p.Word)
+
+	return r
+}
+
+// Creates n games sharing secretWord, such as for a tournament. An empty
+// secretWord generates one secret for the whole batch. Each game is saved as
+// it is created; if one fails, the games created so far are returned with the
+// error.
+func CreateBatch(secretWord string, n int) ([]Game, error) {
+	if n < 1 {
+		return nil, ErrBatchSize
+	}
+	if len(secretWord) < 1 {
+		var err error
+		if secretWord, err = dictionary.GenerateWord(); err != nil {
+			return nil, err
+		}
+	}
+
+	games := make([]Game, 0, n)
+	for i := 0; i < n; i++ {
+		game, err := Create(secretWord)
+		if err != nil {
+			return games, err
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"aluance.io/wordleserver/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayBatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g1, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	g2, err := Create("bless")
+	require.NoError(err, "Create() returned error when creating Game")
+	g3, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	g3.Resign()
+	id1, id2, id3 := g1.(*wordleGame).Id, g2.(*wordleGame).Id, g3.(*wordleGame).Id
+
+	tests := []struct {
+		play   BatchPlay
+		status string
+		err    error
+	}{
+		{play: BatchPlay{ID: id1, Word: "puppy"}, status: "InPlay"},
+		{play: BatchPlay{ID: id2, Word: "bless"}, status: "Won"},
+		{play: BatchPlay{ID: id1, Word: "zzzzz"}, status: "InPlay", err: ErrInvalidWord},
+		{play: BatchPlay{ID: "missing0001", Word: "happy"}, err: ErrGameNotFound},
+		{play: BatchPlay{ID: id3, Word: "happy"}, status: "Resigned", err: ErrGameOver},
+	}
+
+	plays := make([]BatchPlay, len(tests))
+	for i, test := range tests {
+		plays[i] = test.play
+	}
+	results, err := PlayBatch(plays)
+	require.NoError(err)
+	require.Len(results, len(tests))
+
+	for i, test := range tests {
+		r := results[i]
+		assert.Equal(test.play.ID, r.ID)
+		if test.err != nil {
+			assert.ErrorIs(r.Err, test.err, test.play.Word)
+		} else {
+			assert.NoError(r.Err, test.play.Word)
+		}
+		if test.status == "" {
+			assert.Empty(r.Result)
+			continue
+		}
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(r.Result), &out))
+		assert.Equal(test.status, out["gameStatus"], test.play.Word)
+	}
+
+	// Both plays on the first game were applied, in either order
+	assert.Len(g1.(*wordleGame).Attempts, 2)
+
+	results, err = PlayBatch(nil)
+	assert.NoError(err)
+	assert.Empty(results)
+}
+
+// Fails every save after the first saves
+type failingStore struct {
+	fakeStore
+	saves int
+}
+
+func (s *failingStore) Save(id string, content interface{}) error {
+	if s.saves < 1 {
+		return errors.New("store unavailable")
+	}
+	s.saves--
+	return s.fakeStore.Save(id, content)
+}
+
+func TestCreateBatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		secret string
+		n      int
+		err    error
+	}{
+		{secret: "happy", n: 1},
+		{secret: "happy", n: 5},
+		{secret: "", n: 4},
+		{secret: "qzxvw", n: 3, err: ErrInvalidWord},
+		{secret: "happy", n: 0, err: ErrBatchSize},
+	}
+
+	for _, test := range tests {
+		games, err := CreateBatch(test.secret, test.n)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, test.secret)
+			assert.Empty(games, test.secret)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err, test.secret)
+		require.Len(games, test.n)
+
+		// The games share the secret, have distinct IDs and are saved
+		secret := games[0].(*wordleGame).SecretWord
+		ids := map[string]bool{}
+		for _, g := range games {
+			v := g.(*wordleGame)
+			assert.Equal(secret, v.SecretWord)
+			ids[v.Id] = true
+
+			stored, err := Retrieve(v.Id)
+			require.NoError(err)
+			assert.Equal(secret, stored.(*wordleGame).SecretWord)
+		}
+		assert.Len(ids, test.n)
+		if test.secret != "" {
+			assert.Equal(strings.ToUpper(test.secret), secret)
+		}
+	}
+
+	// A failed save returns the games saved before it
+	fs := &failingStore{fakeStore: fakeStore{saved: map[string]interface{}{}}, saves: 2}
+	defer store.SetStore(fs)()
+	games, err := CreateBatch("happy", 5)
+	assert.Error(err)
+	assert.Len(games, 2)
+	assert.Len(fs.saved, 2)
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"aluance.io/wordleserver/internal/dictionary"
+)
+
+// Reports whether at least one dictionary word is still consistent with the
+// hints of every valid attempt, i.e. whether the game can still be won.
+func (g wordleGame) IsSolvable() (bool, error) {
+	if g.GameStatus != InPlay {
+		return false, ErrGameOver
+	}
+
+	release := acquireSolver()
+	defer release()
+
+	words, err := dictionary.WordsOfLength(g.wordLength())
+	if err != nil {
+		return false, err
+	}
+
+	for _, w := range words {
+		if g.isCandidate(strings.ToUpper(w)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Groups the remaining candidates by the hints word would receive if each were
+// the secret. Keys are encoded with EncodeHints; the counts sum to the number
+// of candidates.
+func (g wordleGame) GuessPartition(word string) (map[string]int, error) {
+	w, err := validateWordLength(word, g.wordLength())
+	if err != nil {
+		return nil, err
+	}
+
+	release := acquireSolver()
+	defer release()
+
+	cands, err := g.candidates()
+	if err != nil {
+		return nil, err
+	}
+
+	partition := map[string]int{}
+	score := make([]LetterHint, g.wordLength())
+	for _, c := range cands {
+		probe := wordleGame{SecretWord: c}
+		if err := probe.scoreWord(w, &score); err != nil {
+			return nil, err
+		}
+		partition[EncodeHints(score)]++
+	}
+
+	return partition, nil
+}
+
+// Returns the dictionary words (upper case) of the game's length consistent
+// with all valid attempts
+func (g wordleGame) candidates() ([]string, error) {
+	words, err := dictionary.WordsOfLength(g.wordLength())
+	if err != nil {
+		return nil, err
+	}
+
+	cands := []string{}
+	for _, w := range words {
+		w = strings.ToUpper(w)
+		if g.isCandidate(w) {
+			cands = append(cands, w)
+		}
+	}
+
+	return cands, nil
+}
+
+// A word is a candidate if, were it the secret, every valid attempt would have
+// produced exactly the hints that were recorded.
+func (g wordleGame) isCandidate(word string) bool {
+	if utf8.RuneCountInString(word) != g.wordLength() {
+		return false
+	}
+
+	probe := wordleGame{SecretWord: word}
+	score := make([]LetterHint, g.wordLength())
+	for _, a := range g.Attempts {
+		if !a.IsValidWord {
+			continue
+		}
+		if err := probe.scoreWord(a.word(), &score); err != nil {
+			return false
+		}
+		for i := range score {
+			if score[i] != a.TryResult[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
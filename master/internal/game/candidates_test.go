@@ -0,0 +1,162 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSolvable(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		tryWords   []string
+		attempts   []*WordleAttempt
+		result     bool
+		err        error
+	}{
+		{createWord: "happy", result: true},
+		{createWord: "happy", tryWords: []string{"puppy", "bless"}, result: true},
+		{createWord: "happy", tryWords: []string{"happy"}, err: ErrGameOver},
+		// No word can have every letter of "bless" green and also be all grey
+		{createWord: "happy", attempts: []*WordleAttempt{
+			{TryWord: "BLESS", IsValidWord: true, TryResult: []LetterHint{Green, Green, Green, Green, Green}},
+			{TryWord: "BLESS", IsValidWord: true, TryResult: []LetterHint{Grey, Grey, Grey, Grey, Grey}},
+		}, result: false},
+	}
+
+	for _, test := range tests {
+		game, err := Create(test.createWord)
+		require.NoError(err, "Create() returned error when creating Game")
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+
+		for _, tw := range test.tryWords {
+			game.Play(tw)
+		}
+		v.Attempts = append(v.Attempts, test.attempts...)
+
+		res, err := game.IsSolvable()
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err)
+		assert.Equal(test.result, res, strings.Join(test.tryWords, ","))
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+
+	all, err := v.candidates()
+	require.NoError(err)
+
+	_, err = game.Play("puppy")
+	require.NoError(err)
+	cands, err := v.candidates()
+	require.NoError(err)
+
+	assert.Less(len(cands), len(all))
+	assert.Contains(cands, "HAPPY")
+	for _, c := range cands {
+		assert.True(strings.HasSuffix(c, "PPY"), c)
+	}
+
+	// Games of other lengths draw candidates from words of their length
+	game, err = CreateWithOptions("pear", GameOptions{WordLength: 4})
+	require.NoError(err)
+	_, err = game.Play("bear")
+	require.NoError(err)
+	cands, err = game.(*wordleGame).candidates()
+	require.NoError(err)
+	assert.Contains(cands, "PEAR")
+	for _, c := range cands {
+		assert.True(strings.HasSuffix(c, "EAR"), c)
+	}
+	ok, err = game.IsSolvable()
+	require.NoError(err)
+	assert.True(ok)
+}
+
+func TestEliminated(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+
+	all, err := v.candidates()
+	require.NoError(err)
+
+	total := 0
+	remaining := len(all)
+	for _, tw := range []string{"bless", "zzzzz", "puppy", "happy"} {
+		game.Play(tw)
+		cands, err := v.candidates()
+		require.NoError(err)
+
+		last := v.Attempts[len(v.Attempts)-1]
+		if !last.IsValidWord {
+			assert.Zero(last.Eliminated, tw)
+		}
+		assert.Equal(remaining-len(cands), last.Eliminated, tw)
+		assert.GreaterOrEqual(last.Eliminated, 0)
+
+		total += last.Eliminated
+		remaining = len(cands)
+	}
+
+	// Eliminations add up to the shrinking of the candidate set
+	assert.Equal(len(all)-remaining, total)
+	assert.Equal(1, remaining)
+}
+
+func TestGuessPartition(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	_, err = game.Play("puppy")
+	require.NoError(err)
+
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+	cands, err := v.candidates()
+	require.NoError(err)
+	require.Greater(len(cands), 1)
+
+	tests := []string{"happy", "heave", "bless"}
+	for _, tw := range tests {
+		partition, err := game.GuessPartition(tw)
+		require.NoError(err)
+
+		total := 0
+		for k, n := range partition {
+			assert.Len(k, 5)
+			total += n
+		}
+		assert.Equal(len(cands), total, tw)
+	}
+
+	// Guessing a candidate puts exactly that candidate in the all-green group
+	partition, err := game.GuessPartition("happy")
+	require.NoError(err)
+	assert.Equal(1, partition["GGGGG"])
+
+	_, err = game.GuessPartition("zzzzz")
+	assert.ErrorIs(err, ErrInvalidWord)
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := CreateWithOptions("happy", GameOptions{NoAnagramRepeats: true})
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+	game.Play("heave")
+	game.Play("zzzzz")
+	game.Play("paint")
+	game.SetNote("close")
+	original := game.(*wordleGame)
+
+	tests := []struct {
+		name  string
+		codec store.Codec
+	}{
+		{name: "json", codec: store.JSONCodec},
+		{name: "gob", codec: store.GobCodec},
+	}
+
+	for _, test := range tests {
+		b, err := test.codec.Marshal(original)
+		require.NoError(err, test.name)
+		assert.Equal(test.name == "json", json.Valid(b), test.name) // gob doesn't wrap JSON
+
+		g := &wordleGame{}
+		require.NoError(test.codec.Unmarshal(b, g), test.name)
+
+		assert.Equal(original.Id, g.Id, test.name)
+		assert.Equal(original.GameStatus, g.GameStatus, test.name)
+		assert.Equal(original.SecretWord, g.SecretWord, test.name)
+		assert.Equal(original.ValidAttempts, g.ValidAttempts, test.name)
+		assert.Equal(original.Options, g.Options, test.name)
+		assert.Equal(original.PlayerNote, g.PlayerNote, test.name)
+		require.Len(g.Attempts, len(original.Attempts), test.name)
+		for i, a := range original.Attempts {
+			assert.Equal(a.TryWord, g.Attempts[i].TryWord, test.name)
+			assert.Equal(a.TryResult, g.Attempts[i].TryResult, test.name)
+			assert.True(a.TimeStamp.Equal(g.Attempts[i].TimeStamp), test.name)
+		}
+
+		// The reconstructed game plays on like the original
+		_, err = g.play("happy")
+		require.NoError(err, test.name)
+		assert.Equal(Won, g.GameStatus, test.name)
+		assert.Equal(InPlay, original.GameStatus, test.name)
+	}
+}
+
+func TestGobUpgrade(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// A game encoded before the schema was versioned is upgraded on decoding
+	legacy := &wordleGame{Id: "legacy0003", SecretWord: "HAPPY", GameStatus: InPlay}
+	b, err := store.GobCodec.Marshal(legacy)
+	require.NoError(err)
+
+	g := &wordleGame{}
+	require.NoError(store.GobCodec.Unmarshal(b, g))
+	assert.Equal(gameSchemaVersion, g.SchemaVersion)
+	assert.Equal(5, g.WordLength)
+	assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS, g.MaxAttempts)
+	assert.NotNil(g.Attempts)
+
+	assert.Error(g.UnmarshalBinary([]byte("{not gob")))
+}
@@ -0,0 +1,86 @@
+package game
+
+// Scores from 0 to 1 how well a finished game's guesses respected what was
+// already known. Each valid guess after the first is checked against the
+// hints of the guesses before it: greens kept in place, yellow letters
+// reused and letters known to be absent avoided. The score is the average
+// share of constraints each guess satisfied; with nothing to check it is 1.
+func (g wordleGame) DeductionScore() (float64, error) {
+	if !g.IsFinished() {
+		return 0, ErrGameInPlay
+	}
+
+	greens := map[int]rune{}
+	present := map[rune]bool{}
+	absent := map[rune]bool{}
+	total, checked := 0.0, 0
+	for _, a := range g.Attempts {
+		if !a.IsValidWord {
+			continue
+		}
+		word := []rune(a.word())
+
+		if n := len(greens) + len(present) + len(absent); n > 0 {
+			satisfied := 0
+			for i, c := range greens {
+				if i < len(word) && word[i] == c {
+					satisfied++
+				}
+			}
+			for c := range present {
+				if containsRune(word, c) {
+					satisfied++
+				}
+			}
+			for c := range absent {
+				if !containsRune(word, c) {
+					satisfied++
+				}
+			}
+			total += float64(satisfied) / float64(n)
+			checked++
+		}
+
+		// Add this guess's hints to what is known
+		for i := 0; i < len(word) && i < len(a.TryResult); i++ {
+			switch a.TryResult[i] {
+			case Green:
+				greens[i] = word[i]
+				delete(absent, word[i])
+			case Yellow:
+				present[word[i]] = true
+				delete(absent, word[i])
+			}
+		}
+		for i := 0; i < len(word) && i < len(a.TryResult); i++ {
+			if a.TryResult[i] == Grey && !present[word[i]] && !isGreenLetter(greens, word[i]) {
+				absent[word[i]] = true
+			}
+		}
+	}
+
+	if checked < 1 {
+		return 1, nil
+	}
+	return total / float64(checked), nil
+}
+
+func isGreenLetter(greens map[int]rune, c rune) bool {
+	for _, g := range greens {
+		if g == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsRune(word []rune, c rune) bool {
+	for _, r := range word {
+		if r == c {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeductionScore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		name    string
+		guesses []string
+		min     float64
+		max     float64
+	}{
+		{name: "disciplined", guesses: []string{"heave", "handy", "happy"}, min: 1, max: 1},
+		{name: "sloppy", guesses: []string{"heave", "seven", "bless", "happy"}, min: 0.4, max: 0.5},
+		{name: "invalid guesses ignored", guesses: []string{"heave", "zzzzz", "handy", "happy"}, min: 1, max: 1},
+		{name: "first try", guesses: []string{"happy"}, min: 1, max: 1},
+	}
+
+	for _, test := range tests {
+		game, err := Create("happy")
+		require.NoError(err, "Create() returned error when creating Game")
+		for _, tw := range test.guesses {
+			game.Play(tw)
+		}
+
+		score, err := game.DeductionScore()
+		require.NoError(err, test.name)
+		assert.GreaterOrEqual(score, test.min, test.name)
+		assert.LessOrEqual(score, test.max, test.name)
+	}
+
+	game, err := Create("happy")
+	require.NoError(err)
+	game.Play("heave")
+	_, err = game.DeductionScore()
+	assert.ErrorIs(err, ErrGameInPlay)
+
+	// A lapsed resignation counts as finished
+	score, err := lapseResign(game).DeductionScore()
+	require.NoError(err)
+	assert.Equal(1.0, score)
+}
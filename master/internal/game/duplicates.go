@@ -0,0 +1,64 @@
+package game
+
+import (
+	"errors"
+	"sort"
+
+	"aluance.io/wordleserver/internal/store"
+)
+
+// Scans the in-play games and returns each secret word used by more than one
+// of them, mapped to the (sorted) IDs of the games using it
+func DuplicateSecrets() (map[string][]string, error) {
+	games, err := gameSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	bySecret := map[string][]string{}
+	for id, g := range games {
+		if g.GameStatus == InPlay {
+			bySecret[g.SecretWord] = append(bySecret[g.SecretWord], id)
+		}
+	}
+
+	dups := map[string][]string{}
+	for secret, ids := range bySecret {
+		if len(ids) > 1 {
+			sort.Strings(ids)
+			dups[secret] = ids
+		}
+	}
+
+	return dups, nil
+}
+
+// Returns a consistent copy of the games in the game store, skipping any
+// content that isn't a game
+func gameSnapshot() (map[string]*wordleGame, error) {
+	s, err := store.WordleStore()
+	if err != nil {
+		return nil, err
+	}
+	ss, ok := s.(store.Snapshotter)
+	if !ok {
+		return nil, ErrNoSnapshot
+	}
+
+	snap, err := ss.Snapshot()
+	if errors.Is(err, store.ErrUnsupported) {
+		return nil, ErrNoSnapshot
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	games := map[string]*wordleGame{}
+	for id, content := range snap {
+		if g, ok := content.(*wordleGame); ok {
+			games[id] = g
+		}
+	}
+
+	return games, nil
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"sort"
+	"testing"
+
+	"aluance.io/wordleserver/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateSecrets(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := store.WordleStore()
+	require.NoError(err)
+	require.NoError(s.PurgeAll())
+
+	dups, err := DuplicateSecrets()
+	require.NoError(err)
+	assert.Empty(dups)
+
+	ids := map[string][]string{}
+	for _, w := range []string{"happy", "happy", "bless", "proxy", "proxy", "proxy", "night"} {
+		game, err := Create(w)
+		require.NoError(err, "Create() returned error when creating Game")
+		v := game.(*wordleGame)
+		ids[v.SecretWord] = append(ids[v.SecretWord], v.Id)
+	}
+
+	// Finished games don't count as active
+	finished, err := Create("night")
+	require.NoError(err)
+	_, err = finished.Resign()
+	require.NoError(err)
+
+	for _, v := range ids {
+		sort.Strings(v)
+	}
+
+	dups, err = DuplicateSecrets()
+	require.NoError(err)
+	assert.Equal(map[string][]string{"HAPPY": ids["HAPPY"], "PROXY": ids["PROXY"]}, dups)
+}
+
+func TestListByStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := store.WordleStore()
+	require.NoError(err)
+	require.NoError(s.PurgeAll())
+
+	ids, err := ListByStatus(InPlay)
+	require.NoError(err)
+	assert.Empty(ids)
+
+	// Create a mix of statuses, and content that isn't a game
+	want := map[GameStatusType][]string{}
+	add := func(g Game) {
+		v := g.(*wordleGame)
+		want[v.GameStatus] = append(want[v.GameStatus], v.Id)
+	}
+	for i := 0; i < 3; i++ {
+		g, err := Create("happy")
+		require.NoError(err)
+		add(g)
+	}
+	won, err := Create("happy")
+	require.NoError(err)
+	_, err = won.Play("happy")
+	require.NoError(err)
+	add(won)
+	lost, err := CreateWithOptions("happy", GameOptions{MaxAttempts: 1})
+	require.NoError(err)
+	_, err = lost.Play("heave")
+	require.NoError(err)
+	add(lost)
+	resigned, err := Create("happy")
+	require.NoError(err)
+	_, err = resigned.Resign()
+	require.NoError(err)
+	add(resigned)
+	require.NoError(s.Save("notagame", "some other content"))
+
+	for _, status := range []GameStatusType{InPlay, Won, Lost, Resigned} {
+		sort.Strings(want[status])
+		ids, err := ListByStatus(status)
+		require.NoError(err)
+		assert.Equal(want[status], ids, status.String())
+	}
+	assert.Len(want[InPlay], 3)
+}
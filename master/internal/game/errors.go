@@ -3,11 +3,30 @@ package game
 import "errors"
 
 var (
-	ErrSerialization = errors.New("game serialization error")
-	ErrGameOver      = errors.New("game is finished")
-	ErrOutOfTurns    = errors.New("out of turns")
-	ErrNilResult     = errors.New("nil result provided")
-	ErrWordLength    = errors.New("invalid word length")
-	ErrInvalidWord   = errors.New("word is not in dictionary")
+	ErrSerialization   = errors.New("game serialization error")
+	ErrGameOver        = errors.New("game is finished")
+	ErrGameInPlay      = errors.New("game is still in play")
+	ErrOutOfTurns      = errors.New("out of turns")
+	ErrNilResult       = errors.New("nil result provided")
+	ErrWordLength      = errors.New("invalid word length")
+	ErrInvalidWord     = errors.New("word is not in dictionary")
+	ErrShareCode       = errors.New("unknown share code")
+	ErrShareGrid       = errors.New("malformed share grid")
+	ErrConcurrency     = errors.New("invalid solver concurrency")
+	ErrAnagramRepeat   = errors.New("guess is an anagram of an earlier guess")
+	ErrNoteLength      = errors.New("note is too long")
+	ErrNoSnapshot      = errors.New("store does not support snapshots")
+	ErrGameNotFound    = errors.New("game not found")
+	ErrNoPendingResign = errors.New("no resignation is pending")
+	ErrHardMode        = errors.New("guess breaks hard mode")
+	ErrNoAttempts      = errors.New("no attempts to undo")
+	ErrUndoDisabled    = errors.New("undo is disabled for this game")
+	ErrNoHint          = errors.New("every letter is already revealed")
+	ErrBatchSize       = errors.New("invalid batch size")
+	ErrAdversarialHint = errors.New("hints are unavailable in adversarial games until one candidate is left")
 	// ErrInvalidId     = errors.New("invalid id")
+
+	// Aliases of the errors above, matching them with errors.Is
+	ErrGameFinished = ErrGameOver
+	ErrWrongLength  = ErrWordLength
 )
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/dictionary"
+)
+
+// Fairness verdict enum
+type Verdict int
+
+const (
+	Pass Verdict = iota
+	Warn
+	Fail
+)
+
+// Describes how fair a word is as a daily puzzle
+type FairnessReport struct {
+	Word      string   `json:"word"`
+	Par       int      `json:"par"`       // solver guesses from the common opener
+	Neighbors int      `json:"neighbors"` // words differing in a single position
+	Rarity    float64  `json:"rarity"`    // 0 is the most common word, 1 the rarest, by dictionary.Rarity
+	Verdict   Verdict  `json:"verdict"`
+	Reasons   []string `json:"reasons"`
+}
+
+// Vets a word before publishing it as a daily puzzle. It fails when the solver
+// can't reach it from config.CONFIG_SOLVER_OPENER within the attempt limit,
+// and warns when it is ambiguous (many one-letter neighbors) or rare.
+func DailyFairness(word string) (FairnessReport, error) {
+	release := acquireSolver()
+	defer release()
+
+	word = strings.ToLower(word)
+	r := FairnessReport{Word: word, Reasons: []string{}}
+
+	par, err := dictionary.Par(word, config.CONFIG_SOLVER_OPENER)
+	if err != nil {
+		return r, err
+	}
+	neighbors, err := dictionary.Neighbors(word)
+	if err != nil {
+		return r, err
+	}
+	rarity, err := dictionary.Rarity(word)
+	if err != nil {
+		return r, err
+	}
+
+	r.Par = par
+	r.Neighbors = len(neighbors)
+	r.Rarity = rarity
+
+	if r.Par > config.CONFIG_GAME_MAXVALIDATTEMPTS {
+		r.Verdict = Fail
+		r.Reasons = append(r.Reasons, fmt.Sprintf("par %d exceeds %d attempts", r.Par, config.CONFIG_GAME_MAXVALIDATTEMPTS))
+	}
+	if r.Neighbors > config.CONFIG_FAIRNESS_MAXNEIGHBORS {
+		r.warn(fmt.Sprintf("%d words differ by a single letter", r.Neighbors))
+	}
+	if r.Rarity > config.CONFIG_FAIRNESS_MAXRARITY {
+		r.warn("word is rare")
+	}
+
+	return r, nil
+}
+
+func (r *FairnessReport) warn(reason string) {
+	if r.Verdict < Warn {
+		r.Verdict = Warn
+	}
+	r.Reasons = append(r.Reasons, reason)
+}
+
+var mapVerdictToString = map[Verdict]string{
+	Pass: "Pass",
+	Warn: "Warn",
+	Fail: "Fail",
+}
+
+func (v Verdict) String() string {
+	if s, ok := mapVerdictToString[v]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+func (v Verdict) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString(`"`)
+	buf.WriteString(mapVerdictToString[v])
+	buf.WriteString(`"`)
+	return buf.Bytes(), nil
+}
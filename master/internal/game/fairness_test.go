@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/dictionary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyFairness(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		word    string
+		verdict Verdict
+		err     error
+	}{
+		{word: "proxy", verdict: Pass},
+		{word: "ocean", verdict: Pass},
+		{word: "night", verdict: Warn},
+		{word: "world", verdict: Pass}, // common, though last alphabetically
+		{word: "abets", verdict: Warn}, // rare, though first alphabetically
+		{word: "xxxxx", err: dictionary.ErrUnknownWord},
+	}
+
+	for _, test := range tests {
+		r, err := DailyFairness(test.word)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err)
+		assert.Equal(test.verdict, r.Verdict, "%s: %v", test.word, r)
+		assert.LessOrEqual(r.Par, config.CONFIG_GAME_MAXVALIDATTEMPTS)
+		if test.verdict == Pass {
+			assert.Empty(r.Reasons)
+		} else {
+			assert.NotEmpty(r.Reasons)
+		}
+	}
+}
@@ -1,16 +1,55 @@
 /*
 Package game implements the Wordle game functionality.
 
-This package is intended to be exposed through a RESTful API.
+This package is exposed through the RESTful API of package api.
 
 The primary interface is Game.
 
 Key functions:
 	Create(secretWord) - Returns a new game, where secretWord is the five-letter word to be guessed.
+	CreateWithOptions(secretWord, opts) - Returns a new game using non-default GameOptions, such as the word length.
+	CreateSeeded(seed) - Returns a new game whose secret word is chosen reproducibly by seed.
+	CreateDaily(date) - Returns a new game for the daily puzzle of the given date.
+	CreateBatch(secretWord, n) - Returns n new games sharing one secret word.
+	CreateAdversarial(opts) - Returns a new game whose secret word is chosen to keep the most candidates.
+	MarshalGame(game), UnmarshalGame(b) - Encode and decode a game for persistent stores.
+	ListPaged(offset, limit) - Returns a page of the stored game IDs and the total number of games.
+	ListByStatus(status) - Returns the IDs of the stored games with the given status.
+	PublicReport(report) - Strips the private note from a report returned by Play, Resign or the other game actions.
 
 	Game.Play(tryWord)	- Attempt a guess by passing in a five-letter word. Returns hints for each letter in the guess.
 	Game.Resign() - End the game before winning or losing.
+	Game.ConfirmResign() - Make a pending resignation final before its grace period ends.
+	Game.CancelResign() - Withdraw a pending resignation.
+	Game.Undo() - Take back the last attempt, for practice games.
+	Game.Hint() - Reveals one secret letter the player hasn't found.
+	Game.Restart() - Returns a new game with the same secret word.
 	Game.Describe() - Returns a represantation of the game object state (including the secret word).
+	Game.DescribePublic() - Returns the game state safe for clients: no private note, and the secret word only once finished.
+	Game.SetNote(text) - Attaches a private note to the game, shown by Describe() only.
+	Game.HintMatrix() - Returns only the hints of each attempt, for a color-only board.
+	Game.GetAttempts() - Returns each attempt with its hints, validity and whether it won.
+	Game.KeyboardState() - Returns the strongest hint seen for each letter.
+	Game.RebuildDerived() - Recomputes state derived from the attempts, such as the valid attempt count.
+	Game.RemainingAttempts() - Returns the number of guesses left.
+	Game.AttemptTimings() - Returns the server-side time each attempt was received.
+	Game.Attributes() - Returns flat game attributes for tracing and logs, without the secret word.
+	Game.DebugReport() - Returns the full game state, including rejected guesses.
+	Game.Transitions() - Returns the ordered log of status changes.
+	Game.Elapsed() - Returns how long the game has been going, or how long it took once finished.
+	Game.Status() - Returns the game status; IsWon(), IsLost() and IsFinished() test it.
+	Game.GuessPartition(word) - Counts the remaining candidates by the hints word would receive.
+	Game.IsSolvable() - Reports whether any dictionary word is still consistent with the hints.
+	Game.WinProbability() - Estimates the chance of finding the secret in the remaining guesses.
+	Game.OptimalLine() - Returns the guesses the solver would make to find a finished game's secret word.
+	Game.AlignmentScore() - Returns the fraction of a finished game's guesses that match the optimal line.
+	Game.DeductionScore() - Scores how well a finished game's guesses respected earlier hints.
+	Game.SuggestBeginner() - Suggests an unused word testing the most common remaining letters, for new players.
+	Game.SuggestCandidateOnly() - Suggests the remaining candidate that best splits the others.
+	Game.ShareGrid() - Returns the shareable emoji grid of a finished game.
+	Game.ShareString(opts) - Returns the emoji grid rendered with ShareOptions, such as colorblind squares.
+	Game.RenderAccessible() - Renders the board with symbols instead of colors.
+	Game.ShareCode() - Returns a short code that can be resolved back to the game with ResolveShareCode(code).
 
 */
 
@@ -19,7 +58,10 @@ package game
 import (
 	"bytes"
 	"encoding/json"
-	"strings"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
 
 	"aluance.io/wordleserver/internal/config"
@@ -38,45 +80,185 @@ const (
 	Resigned
 )
 
+// Win type enum, describing how a game was won
+type WinType int
+
+const (
+	NoWin    WinType = iota // game not won
+	Normal                  // won in a middle attempt
+	Clutch                  // won on the last allowed attempt
+	FirstTry                // won on the first attempt
+)
+
+// Records a change of game status
+type StatusTransition struct {
+	From GameStatusType `json:"from"`
+	To   GameStatusType `json:"to"`
+	At   time.Time      `json:"at"`
+}
+
+// Options used to create a game
+type GameOptions struct {
+	WordLength          int  `json:"wordLength,omitempty"`          // letters in the secret and guesses; zero uses the configured length
+	MaxAttempts         int  `json:"maxAttempts,omitempty"`         // valid guesses allowed; zero uses the configured limit
+	RecordRejected      bool `json:"recordRejected,omitempty"`      // keep invalid guesses in RejectedGuesses instead of as attempts
+	NoAnagramRepeats    bool `json:"noAnagramRepeats,omitempty"`    // reject guesses using the same letters as an earlier guess
+	HardMode            bool `json:"hardMode,omitempty"`            // guesses must reuse every revealed hint
+	ShowLetterCountHint bool `json:"showLetterCountHint,omitempty"` // report how many distinct letters the secret has
+	ShowNearWin         bool `json:"showNearWin,omitempty"`         // report when the last guess was one letter away
+	AllowAnySecret      bool `json:"allowAnySecret,omitempty"`      // accept a secret word that isn't in the dictionary
+	AllowUndo           bool `json:"allowUndo,omitempty"`           // allow taking back attempts, for practice games
+	HintCostsAttempt    bool `json:"hintCostsAttempt,omitempty"`    // each hint uses up a guess
+	Adversarial         bool `json:"adversarial,omitempty"`         // the secret is chosen as late as possible, keeping the most candidates
+	SkipInvalidGuesses  bool `json:"skipInvalidGuesses,omitempty"`  // report invalid guesses without keeping them or counting them as attempts
+
+	ResignGracePeriod time.Duration `json:"resignGracePeriod,omitempty"` // delay before a resignation is final
+	TTL               time.Duration `json:"ttl,omitempty"`               // delete the game this long after it is created, on stores supporting expiry
+}
+
 // Game interface
 type Game interface {
 	Describe() (string, error)
+	DescribePublic() (string, error)
 	Play(tryWord string) (string, error)
 	Resign() (string, error)
+	ConfirmResign() (string, error)
+	CancelResign() (string, error)
+	Undo() (string, error)
+	Hint() (string, error)
+	Restart() (Game, error)
+	SetNote(text string) error
+	Note() string
+	ShareGrid() (string, error)
+	ShareString(opts ShareOptions) (string, error)
+	ShareCode() (string, error)
+	RenderAccessible() (string, error)
+	IsSolvable() (bool, error)
+	WinProbability() (float64, error)
+	GuessPartition(word string) (map[string]int, error)
+	SuggestBeginner() (string, error)
+	SuggestCandidateOnly() (string, error)
+	OptimalLine() ([]string, error)
+	AlignmentScore() (float64, error)
+	DeductionScore() (float64, error)
+	Transitions() []StatusTransition
+	Elapsed() time.Duration
+	Status() GameStatusType
+	IsWon() bool
+	IsLost() bool
+	IsFinished() bool
+	DebugReport() (string, error)
+	HintMatrix() ([][]LetterHint, error)
+	GetAttempts() ([]AttemptView, error)
+	KeyboardState() (map[string]LetterHint, error)
+	RebuildDerived()
+	AttemptTimings() ([]time.Time, error)
+	RemainingAttempts() int
+	Attributes() map[string]string
 	// State() (string, error)
 }
 
 // Factory used to create a game
 func Create(secretWord string) (Game, error) {
+	return CreateWithOptions(secretWord, GameOptions{})
+}
+
+// Factory used to create a game with non-default options
+func CreateWithOptions(secretWord string, opts GameOptions) (Game, error) {
+	length := opts.WordLength
+	if length < 1 {
+		length = config.CONFIG_GAME_WORDLENGTH
+	}
 	if len(secretWord) < 1 {
 		var err error
-		if secretWord, err = dictionary.GenerateWord(); err != nil {
+		if secretWord, err = dictionary.GenerateWordOfLength(length); err != nil {
 			return nil, err
 		}
 	}
 
-	sw, err := validateWord(secretWord, secretWord)
+	game, err := newGame(secretWord, length, opts.AllowAnySecret)
 	if err != nil {
 		return nil, err
 	}
-	game := &wordleGame{}
-	game.Id = xid.New().String()
-	game.SecretWord = sw
-	game.Attempts = []*WordleAttempt{}
-	game.Status = InPlay
-	game.LastUpdated = time.Now()
+	game.Options = opts
+	if opts.MaxAttempts > 0 {
+		game.MaxAttempts = opts.MaxAttempts
+	}
+	if err := game.save(); err != nil {
+		return game, err
+	}
 
-	s, err := store.WordleStore()
+	return game, nil
+}
+
+// Factory used to create a game whose secret word is chosen by seed. Games
+// created with the same seed and dictionary share the same secret word.
+func CreateSeeded(seed int64) (Game, error) {
+	secretWord, err := dictionary.GenerateWordSeeded(seed)
 	if err != nil {
-		return game, err
+		return nil, err
+	}
+
+	return Create(secretWord)
+}
+
+// Factory used to create the daily puzzle game for a date. All games created
+// for the same date share the same secret word and puzzle number.
+func CreateDaily(date time.Time) (Game, error) {
+	number, err := dictionary.DailyNumber(date)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.Save(game.Id, game); err != nil {
+	secretWord, err := dictionary.DailyWord(date)
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := newGame(secretWord, config.CONFIG_GAME_WORDLENGTH, false)
+	if err != nil {
+		return nil, err
+	}
+	game.Daily = true
+	game.PuzzleNumber = number
+	if err := game.save(); err != nil {
 		return game, err
 	}
 
 	return game, nil
 }
 
+// Returns limit game IDs from offset in sorted order, and the total number of
+// games, for paging through the games in the store
+func ListPaged(offset, limit int) ([]string, int, error) {
+	s, err := store.WordleStore()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.ListPaged(offset, limit)
+}
+
+// Returns the sorted IDs of the stored games with status, skipping content
+// that isn't a game. Resignations whose grace period has ended count as
+// resigned.
+func ListByStatus(status GameStatusType) ([]string, error) {
+	games, err := gameSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for id, g := range games {
+		g.settleResign() // games are copies
+		if g.GameStatus == status {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
 func Retrieve(id string) (Game, error) {
 	s, err := store.WordleStore()
 	if err != nil {
@@ -86,43 +268,117 @@ func Retrieve(id string) (Game, error) {
 	if err != nil {
 		return nil, err
 	}
+	if content == nil {
+		return nil, ErrGameNotFound
+	}
+	// Persistent stores may return the encoded game
+	switch content.(type) {
+	case []byte, string:
+		if content, err = decodeGame(s, content); err != nil {
+			return nil, err
+		}
+	}
 
 	game, ok := content.(Game)
 	if !ok {
 		return nil, ErrSerialization
 	}
+	// Settle under the store's lock so a play landing meanwhile isn't lost
+	if g, ok := game.(*wordleGame); ok && g.settleResign() {
+		if _, err := g.update(func(g *wordleGame, _ string) (string, error) {
+			g.settleResign()
+			return "", nil
+		}, ""); err != nil {
+			return game, err
+		}
+	}
 
 	return game, nil
 }
 
 func (g wordleGame) Describe() (string, error) {
+	g.settleResign() // only settles this copy
 	return g.statusReport(), nil
 }
 
+// Returns the state that is safe to send to any client: the secret word only
+// once the game is finished, and no private note
+func (g wordleGame) DescribePublic() (string, error) {
+	g.settleResign() // only settles this copy
+	g.PlayerNote = ""
+
+	return g.statusReport(), nil
+}
+
+// Returns the full game state, including the secret word and rejected guesses
+func (g wordleGame) DebugReport() (string, error) {
+	b, err := json.Marshal(g)
+	if err != nil {
+		return "{}", err
+	}
+
+	return string(b), nil
+}
+
 func (g *wordleGame) Play(tryWord string) (string, error) {
-	if g.Status != InPlay {
+	return g.update((*wordleGame).play, tryWord)
+}
+
+// Plays tryWord without saving the game
+func (g *wordleGame) play(tryWord string) (string, error) {
+	g.settleResign()
+	if g.ResignPendingUntil != nil {
+		// Playing on withdraws a pending resignation
+		g.ResignPendingUntil = nil
+		g.LastUpdated = now()
+	}
+	if g.GameStatus != InPlay {
 		return g.statusReport(), ErrGameOver
 	}
-	if len(g.Attempts) >= config.CONFIG_GAME_MAXATTEMPTS ||
-		g.ValidAttempts >= config.CONFIG_GAME_MAXVALIDATTEMPTS {
-		g.Status = Lost
+	if g.outOfTurns() {
+		g.setStatus(Lost)
 		return g.statusReport(), ErrOutOfTurns
 	}
 
+	tw, err := validateWordLength(tryWord, g.wordLength(), g.SecretWord)
+	if err != nil && g.Options.RecordRejected {
+		// Rejected guesses are kept for analysis but don't count as attempts
+		g.RejectedGuesses = append(g.RejectedGuesses, tw)
+		g.LastUpdated = now()
+		return g.statusReport(), err
+	}
+	if err != nil && g.Options.SkipInvalidGuesses {
+		return g.rejectedReport(tryWord), err // the game is unchanged
+	}
+
+	if err == nil && g.Options.NoAnagramRepeats && g.isAnagramRepeat(tw) {
+		return g.statusReport(), ErrAnagramRepeat // doesn't consume a turn
+	}
+	if err == nil && g.Options.HardMode {
+		if herr := g.checkHardMode(tw); herr != nil {
+			return g.statusReport(), herr // doesn't consume a turn
+		}
+	}
+
 	attempt := g.addAttempt()
-	tw, err := validateWord(tryWord, g.SecretWord)
-	attempt.TryWord = tw
+	attempt.TryWord = tryWord // scored as tw, but reported as typed
 	if err != nil {
 		attempt.IsValidWord = false
 
-		if len(g.Attempts) >= config.CONFIG_GAME_MAXATTEMPTS ||
-			g.ValidAttempts >= config.CONFIG_GAME_MAXVALIDATTEMPTS {
-			g.Status = Lost
+		if g.outOfTurns() {
+			g.setStatus(Lost)
 		}
-		if err == ErrWordLength {
+		return g.statusReport(), err
+	}
+	before, err := g.candidates()
+	if err != nil {
+		return g.statusReport(), err
+	}
+	if g.Options.Adversarial {
+		// The secret only needs to stay consistent with the earlier hints
+		if g.SecretWord, err = g.adversarialSecret(tw, before); err != nil {
 			return g.statusReport(), err
 		}
-		return g.statusReport(), err
 	}
 	attempt.IsValidWord = true
 	g.ValidAttempts++
@@ -133,47 +389,239 @@ func (g *wordleGame) Play(tryWord string) (string, error) {
 		return g.statusReport(), err
 	}
 
-	// Check for end of game conditions
-	if attempt.isWinner() {
-		g.Status = Won
-	} else if len(g.Attempts) >= config.CONFIG_GAME_MAXATTEMPTS ||
-		g.ValidAttempts >= config.CONFIG_GAME_MAXVALIDATTEMPTS {
-		g.Status = Lost
-	}
-
-	g.LastUpdated = time.Now()
-
-	// Save to game store
-	gs, err := store.WordleStore()
+	after, err := g.candidates()
 	if err != nil {
 		return g.statusReport(), err
 	}
-	err = gs.Save(g.Id, g)
-	if err != nil {
-		return g.statusReport(), err
+	attempt.Eliminated = len(before) - len(after)
+
+	// Check for end of game conditions. A win takes precedence over running
+	// out of turns, so a correct final guess is Won, never Lost.
+	if attempt.isWinner() {
+		g.setStatus(Won)
+	} else if g.outOfTurns() {
+		g.setStatus(Lost)
 	}
 
+	g.LastUpdated = now()
+
 	// Return the attempt as JSON
 	return g.statusReport(), nil
 }
 
+// Returns a copy of each attempt's hints, without words or the secret
+func (g wordleGame) HintMatrix() ([][]LetterHint, error) {
+	m := make([][]LetterHint, len(g.Attempts))
+	for i, a := range g.Attempts {
+		m[i] = append([]LetterHint{}, a.TryResult...)
+	}
+
+	return m, nil
+}
+
+// Returns a copy of every attempt made, in order
+func (g wordleGame) GetAttempts() ([]AttemptView, error) {
+	views := make([]AttemptView, len(g.Attempts))
+	for i, a := range g.Attempts {
+		views[i] = AttemptView{
+			Index:       i,
+			TryWord:     a.TryWord,
+			Hints:       append([]LetterHint{}, a.TryResult...),
+			IsValidWord: a.IsValidWord,
+			IsWinning:   g.GameStatus == Won && i == len(g.Attempts)-1,
+		}
+	}
+
+	return views, nil
+}
+
+// Returns the server-side receipt time of each attempt, in order
+func (g wordleGame) AttemptTimings() ([]time.Time, error) {
+	t := make([]time.Time, len(g.Attempts))
+	for i, a := range g.Attempts {
+		t[i] = a.TimeStamp
+	}
+
+	return t, nil
+}
+
+// Returns a flat set of attributes describing the game, suitable for trace
+// spans and structured logs. The secret word is never included. The mode is
+// how the secret is chosen: "standard", "daily" or "adversarial". Hard mode
+// may apply to any of them, so it is reported separately.
+func (g wordleGame) Attributes() map[string]string {
+	mode := "standard"
+	switch {
+	case g.Daily:
+		mode = "daily"
+	case g.Options.Adversarial:
+		mode = "adversarial"
+	}
+
+	return map[string]string{
+		"game.id":                 g.Id,
+		"game.status":             g.GameStatus.String(),
+		"game.attempts_used":      strconv.Itoa(len(g.Attempts)),
+		"game.max_attempts":       strconv.Itoa(g.maxTotalAttempts()),
+		"game.max_valid_attempts": strconv.Itoa(g.maxAttempts()),
+		"game.word_length":        strconv.Itoa(g.wordLength()),
+		"game.mode":               mode,
+		"game.hard_mode":          strconv.FormatBool(g.Options.HardMode),
+	}
+}
+
+// Attach a private note to the game, replacing any previous note
+func (g *wordleGame) SetNote(text string) error {
+	if len([]rune(text)) > config.CONFIG_GAME_MAXNOTELENGTH {
+		return ErrNoteLength
+	}
+
+	_, err := g.update((*wordleGame).setNote, text)
+	return err
+}
+
+func (g *wordleGame) setNote(text string) (string, error) {
+	g.PlayerNote = text
+	g.LastUpdated = now()
+
+	return "", nil
+}
+
+func (g wordleGame) Note() string {
+	return g.PlayerNote
+}
+
+func (g wordleGame) Transitions() []StatusTransition {
+	t := make([]StatusTransition, len(g.StatusLog))
+	copy(t, g.StatusLog)
+
+	return t
+}
+
+// Returns the status of the game, settling a resignation whose grace period
+// has ended
+func (g wordleGame) Status() GameStatusType {
+	g.settleResign() // only settles this copy
+	return g.GameStatus
+}
+
+func (g wordleGame) IsWon() bool {
+	return g.Status() == Won
+}
+
+func (g wordleGame) IsLost() bool {
+	return g.Status() == Lost
+}
+
+// Reports whether the game is over: won, lost or resigned
+func (g wordleGame) IsFinished() bool {
+	return g.Status() != InPlay
+}
+
+// Returns the time since the game was created, stopping when it finishes.
+// Games stored before creation times were recorded report zero.
+func (g wordleGame) Elapsed() time.Duration {
+	if g.CreatedAt.IsZero() {
+		return 0
+	}
+	if g.GameStatus != InPlay && !g.FinishedAt.IsZero() {
+		return g.FinishedAt.Sub(g.CreatedAt)
+	}
+
+	return now().Sub(g.CreatedAt)
+}
+
+// Resigns the game. With a resign grace period the game stays in play until
+// the period ends, the resignation is confirmed, or it is cancelled by
+// CancelResign or by playing again.
 func (g *wordleGame) Resign() (string, error) {
-	g.Status = Resigned
-	g.LastUpdated = time.Now()
+	return g.update((*wordleGame).resign, "")
+}
 
-	// Save to game store
-	gs, err := store.WordleStore()
-	if err != nil {
-		return g.statusReport(), err
+func (g *wordleGame) resign(string) (string, error) {
+	g.settleResign()
+	if g.ResignPendingUntil != nil {
+		return g.statusReport(), nil // already pending
 	}
-	err = gs.Save(g.Id, g)
-	if err != nil {
-		return g.statusReport(), err
+	if g.GameStatus != InPlay {
+		return g.statusReport(), ErrGameOver
+	}
+	if g.Options.ResignGracePeriod > 0 {
+		until := now().Add(g.Options.ResignGracePeriod)
+		g.ResignPendingUntil = &until
+	} else {
+		g.setStatus(Resigned)
+	}
+	g.LastUpdated = now()
+
+	return g.statusReport(), nil
+}
+
+// Makes a pending resignation final without waiting for the grace period
+func (g *wordleGame) ConfirmResign() (string, error) {
+	return g.update((*wordleGame).confirmResign, "")
+}
+
+func (g *wordleGame) confirmResign(string) (string, error) {
+	g.settleResign()
+	if g.ResignPendingUntil == nil {
+		return g.statusReport(), ErrNoPendingResign
+	}
+
+	g.ResignPendingUntil = nil
+	g.setStatus(Resigned)
+	g.LastUpdated = now()
+
+	return g.statusReport(), nil
+}
+
+// Withdraws a pending resignation, leaving the game in play
+func (g *wordleGame) CancelResign() (string, error) {
+	return g.update((*wordleGame).cancelResign, "")
+}
+
+func (g *wordleGame) cancelResign(string) (string, error) {
+	g.settleResign()
+	if g.ResignPendingUntil == nil {
+		return g.statusReport(), ErrNoPendingResign
 	}
 
+	g.ResignPendingUntil = nil
+	g.LastUpdated = now()
+
 	return g.statusReport(), nil
 }
 
+// Creates and saves a new game with the same secret word and options, so the
+// word can be tried again. This game is unchanged.
+func (g wordleGame) Restart() (Game, error) {
+	game, err := newGame(g.SecretWord, g.wordLength(), true)
+	if err != nil {
+		return nil, err
+	}
+	game.Options = g.Options
+	game.MaxAttempts = g.maxAttempts()
+	if err := game.save(); err != nil {
+		return game, err
+	}
+
+	return game, nil
+}
+
+// Makes a pending resignation final once its grace period has passed.
+// Reports whether the game changed.
+func (g *wordleGame) settleResign() bool {
+	if g.ResignPendingUntil == nil || now().Before(*g.ResignPendingUntil) {
+		return false
+	}
+
+	g.ResignPendingUntil = nil
+	g.setStatus(Resigned)
+	g.LastUpdated = now()
+
+	return true
+}
+
 func (t GameStatusType) MarshalJSON() ([]byte, error) {
 	buf := bytes.NewBufferString(`"`)
 	buf.WriteString(mapGameStatusToString[t])
@@ -207,6 +655,44 @@ var mapStringToGameStatus = map[string]GameStatusType{
 	"Resigned": Resigned,
 }
 
+var mapWinTypeToString = map[WinType]string{
+	NoWin:    "NoWin",
+	Normal:   "Normal",
+	Clutch:   "Clutch",
+	FirstTry: "FirstTry",
+}
+
+var mapStringToWinType = map[string]WinType{
+	"NoWin":    NoWin,
+	"Normal":   Normal,
+	"Clutch":   Clutch,
+	"FirstTry": FirstTry,
+}
+
+func (w WinType) String() string {
+	if s, ok := mapWinTypeToString[w]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+func (w WinType) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString(`"`)
+	buf.WriteString(mapWinTypeToString[w])
+	buf.WriteString(`"`)
+	return buf.Bytes(), nil
+}
+
+func (w *WinType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	*w = mapStringToWinType[s]
+	return nil
+}
+
 func (t GameStatusType) String() string {
 	if s, ok := mapGameStatusToString[t]; ok {
 		return s
@@ -215,54 +701,336 @@ func (t GameStatusType) String() string {
 }
 
 type wordleGame struct {
-	Id            string           `json:"id"`
-	Status        GameStatusType   `json:"gameStatus"`
-	SecretWord    string           `json:"secretWord"`
-	Attempts      []*WordleAttempt `json:"attempts"`
-	ValidAttempts int              `json:"validAttempts"`
-	LastUpdated   time.Time        `json:"lastUpdated"`
+	SchemaVersion   int                `json:"schemaVersion"`
+	Id              string             `json:"id"`
+	GameStatus      GameStatusType     `json:"gameStatus"`
+	SecretWord      string             `json:"secretWord"`
+	WordLength      int                `json:"wordLength"`
+	MaxAttempts     int                `json:"maxAttempts"`
+	Attempts        []*WordleAttempt   `json:"attempts"`
+	ValidAttempts   int                `json:"validAttempts"`
+	LastUpdated     time.Time          `json:"lastUpdated"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	FinishedAt      time.Time          `json:"finishedAt"`
+	ShareText       string             `json:"shareText,omitempty"`
+	HintsUsed       int                `json:"hintsUsed"`
+	HintedPositions []int              `json:"hintedPositions,omitempty"`
+	ShareKey        string             `json:"shareCode,omitempty"`
+	Daily           bool               `json:"daily,omitempty"`
+	PuzzleNumber    int                `json:"puzzleNumber"`
+	StatusLog       []StatusTransition `json:"transitions,omitempty"`
+	Options         GameOptions        `json:"options"`
+	RejectedGuesses []string           `json:"rejectedGuesses,omitempty"`
+	PlayerNote      string             `json:"note,omitempty"`
+
+	ResignPendingUntil *time.Time `json:"resignPendingUntil,omitempty"`
 }
 
-func (g *wordleGame) addAttempt() *WordleAttempt {
-	wa := new(WordleAttempt)
+// Generates IDs for new games
+var idGenerator = defaultIdGenerator
+var idMu sync.Mutex
 
-	wa.TimeStamp = time.Now()
-	wa.TryWord = ""
-	wa.IsValidWord = false
-	wa.TryResult = make([]LetterHint, config.CONFIG_GAME_WORDLENGTH)
+func defaultIdGenerator() string {
+	return xid.New().String()
+}
 
-	g.Attempts = append(g.Attempts, wa)
-	g.LastUpdated = time.Now()
+// Set the function used to generate IDs for new games. Passing nil restores
+// the default xid generator. Generated IDs must still be valid store IDs.
+func SetIDGenerator(fn func() string) {
+	idMu.Lock()
+	defer idMu.Unlock()
 
-	return wa
+	if fn == nil {
+		fn = defaultIdGenerator
+	}
+	idGenerator = fn
 }
 
-func (g wordleGame) statusReport() string {
-	b, err := json.Marshal(g)
+func newId() (string, error) {
+	idMu.Lock()
+	id := idGenerator()
+	idMu.Unlock()
+
+	if err := store.ValidateId(id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func newGame(secretWord string, length int, anySecret bool) (*wordleGame, error) {
+	// Secrets must be dictionary words, so the game can be won by the rules,
+	// unless any secret is allowed
+	check := []interface{}{}
+	if anySecret {
+		check = append(check, secretWord)
+	}
+	sw, err := validateWordLength(secretWord, length, check...)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newId()
 	if err != nil {
-		return "{}"
+		return nil, err
+	}
+	game := &wordleGame{}
+	game.SchemaVersion = gameSchemaVersion
+	game.Id = id
+	game.SecretWord = sw
+	game.WordLength = length
+	game.MaxAttempts = config.CONFIG_GAME_MAXVALIDATTEMPTS
+	game.Attempts = []*WordleAttempt{}
+	game.GameStatus = InPlay
+	game.CreatedAt = now()
+	game.LastUpdated = game.CreatedAt
+
+	return game, nil
+}
+
+// Returns a deep copy of the game, used for store snapshots
+func (g *wordleGame) Clone() interface{} {
+	c := *g
+	c.Attempts = make([]*WordleAttempt, len(g.Attempts))
+	for i, a := range g.Attempts {
+		ac := *a
+		ac.TryResult = append([]LetterHint{}, a.TryResult...)
+		c.Attempts[i] = &ac
+	}
+	c.StatusLog = append([]StatusTransition(nil), g.StatusLog...)
+	c.HintedPositions = append([]int(nil), g.HintedPositions...)
+	c.RejectedGuesses = append([]string(nil), g.RejectedGuesses...)
+	if g.ResignPendingUntil != nil {
+		until := *g.ResignPendingUntil
+		c.ResignPendingUntil = &until
 	}
 
-	s := map[string]interface{}{}
-	err = json.Unmarshal(b, &s)
+	return &c
+}
+
+// Saves the game. Games with the TTL option expire that long after they were
+// created, if the store supports expiry.
+func (g *wordleGame) save() error {
+	s, err := store.WordleStore()
 	if err != nil {
-		return "{}"
+		return err
 	}
 
-	s["attemptsUsed"] = len(g.Attempts)
-	if g.Status == InPlay {
-		delete(s, "secretWord")
+	if ts, ok := s.(store.TTLSaver); ok && g.Options.TTL > 0 {
+		if ttl := g.CreatedAt.Add(g.Options.TTL).Sub(now()); ttl > 0 {
+			// Wrapping stores may not support it for their inner store
+			if err := ts.SaveWithTTL(g.Id, g, ttl); !errors.Is(err, store.ErrUnsupported) {
+				return err
+			}
+		}
 	}
-	if g.Status == Won {
-		s["winningAttempt"] = len(g.Attempts)
+
+	return s.Save(g.Id, g)
+}
+
+// Applies op to the stored state of the game and saves it, atomically with
+// Store.Update so concurrent changes to the same game are not lost. The
+// result is copied back into g. The game is saved even when op returns an
+// error, since rejected guesses may still change it. A game that is no longer
+// stored, because it was deleted or has expired, returns ErrGameNotFound.
+func (g *wordleGame) update(op func(*wordleGame, string) (string, error), arg string) (string, error) {
+	s, err := store.WordleStore()
+	if err != nil {
+		return g.statusReport(), err
 	}
 
-	b, err = json.Marshal(s)
+	var report string
+	var opErr error
+	err = s.Update(g.Id, func(content interface{}) (interface{}, error) {
+		if content == nil {
+			return nil, ErrGameNotFound
+		}
+		current, err := decodeGame(s, content)
+		if err != nil {
+			return nil, err
+		}
+
+		report, opErr = op(current, arg)
+		if current != g {
+			*g = *current.Clone().(*wordleGame)
+		}
+		return current, nil
+	})
 	if err != nil {
-		return "{}"
+		return g.statusReport(), err
+	}
+
+	return report, opErr
+}
+
+// Set the game status, logging the transition and caching the share text
+// once the game is finished
+func (g *wordleGame) setStatus(s GameStatusType) {
+	if s != g.GameStatus {
+		g.StatusLog = append(g.StatusLog, StatusTransition{From: g.GameStatus, To: s, At: now()})
+		g.FinishedAt = time.Time{}
+		if s != InPlay {
+			g.FinishedAt = now()
+		}
+	}
+	g.GameStatus = s
+	if s != InPlay {
+		g.ShareText = g.shareText()
+	}
+}
+
+// Reports whether word uses the same multiset of letters as an earlier valid attempt
+func (g wordleGame) isAnagramRepeat(word string) bool {
+	key := anagramKey(word)
+	for _, a := range g.Attempts {
+		if a.IsValidWord && anagramKey(a.TryWord) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the number of letters in the game's words. Games stored before the
+// length was recorded use the configured length.
+func (g wordleGame) wordLength() int {
+	if g.WordLength > 0 {
+		return g.WordLength
+	}
+	return config.CONFIG_GAME_WORDLENGTH
+}
+
+// Returns the number of valid guesses allowed. Games stored before the limit
+// was recorded use the configured limit.
+func (g wordleGame) maxAttempts() int {
+	if g.MaxAttempts > 0 {
+		return g.MaxAttempts
+	}
+	return config.CONFIG_GAME_MAXVALIDATTEMPTS
+}
+
+// Returns the number of attempts allowed including invalid words, keeping the
+// configured allowance of invalid words on top of the valid guesses.
+func (g wordleGame) maxTotalAttempts() int {
+	return g.maxAttempts() + config.CONFIG_GAME_MAXATTEMPTS - config.CONFIG_GAME_MAXVALIDATTEMPTS
+}
+
+// Returns the most valid guesses the game allows once the guesses used up by
+// hints and the total limit, which also counts invalid words, are applied
+func (g wordleGame) guessLimit() int {
+	limit := g.maxAttempts() - g.hintCost()
+	if total := g.maxTotalAttempts(); total < limit {
+		limit = total
+	}
+
+	return limit
+}
+
+// Reports whether the last attempt was a valid word with all but one letter green
+func (g wordleGame) isNearWin() bool {
+	if len(g.Attempts) < 1 {
+		return false
+	}
+	a := g.Attempts[len(g.Attempts)-1]
+	if !a.IsValidWord {
+		return false
+	}
+
+	greens := 0
+	for _, h := range a.TryResult {
+		if h == Green {
+			greens++
+		}
+	}
+	return greens == g.wordLength()-1
+}
+
+// Returns the number of guesses left. Valid guesses are limited by the attempt
+// limit, and all guesses, including invalid words, by the total limit. Once
+// the game is over this is the number that went unused.
+func (g wordleGame) RemainingAttempts() int {
+	remaining := g.maxAttempts() - g.ValidAttempts - g.hintCost()
+	if total := g.maxTotalAttempts() - len(g.Attempts); total < remaining {
+		remaining = total
+	}
+	if remaining < 0 {
+		return 0
 	}
 
-	return string(b)
+	return remaining
+}
+
+func (g wordleGame) outOfTurns() bool {
+	return len(g.Attempts) >= g.maxTotalAttempts() ||
+		g.ValidAttempts+g.hintCost() >= g.maxAttempts()
+}
+
+func (g *wordleGame) addAttempt() *WordleAttempt {
+	wa := new(WordleAttempt)
+
+	wa.TimeStamp = serverTime()
+	if n := len(g.Attempts); n > 0 && wa.TimeStamp.Before(g.Attempts[n-1].TimeStamp) {
+		wa.TimeStamp = g.Attempts[n-1].TimeStamp
+	}
+	wa.TryWord = ""
+	wa.IsValidWord = false
+	wa.TryResult = make([]LetterHint, g.wordLength())
+
+	g.Attempts = append(g.Attempts, wa)
+	g.LastUpdated = now()
+
+	return wa
+}
+
+// Returns the current time wherever the game records times
+var nowFunc = time.Now
+var clockMu sync.RWMutex
+
+// Set the clock used for game times, so tests can control the current time.
+// Passing nil restores time.Now.
+func SetClock(fn func() time.Time) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	if fn == nil {
+		fn = time.Now
+	}
+	nowFunc = fn
+}
+
+// Restores the system clock after SetClock
+func ResetClock() {
+	SetClock(nil)
+}
+
+func now() time.Time {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+
+	return nowFunc()
+}
+
+// Wall-clock time measured on the monotonic clock from process start, so that
+// system clock adjustments cannot reorder attempt timestamps. A clock set by
+// SetClock has no monotonic reading, so its times are returned unchanged.
+var clockAnchor = time.Now()
+
+func serverTime() time.Time {
+	t := now()
+	return clockAnchor.Add(t.Sub(clockAnchor)).In(t.Location()).Round(0)
+}
+
+// Classify a win by the number of valid attempts it took
+func (g wordleGame) winType() WinType {
+	switch {
+	case g.GameStatus != Won:
+		return NoWin
+	case g.ValidAttempts == 1:
+		return FirstTry
+	case g.ValidAttempts >= g.maxAttempts():
+		return Clutch
+	}
+
+	return Normal
 }
 
 func (g wordleGame) scoreWord(tryWord string, result *[]LetterHint) error {
@@ -271,34 +1039,37 @@ func (g wordleGame) scoreWord(tryWord string, result *[]LetterHint) error {
 	}
 	score := *result
 
-	// Rules for scoring:
-	// 1. If the correct letter is in the correct location, mark it green
-	// 2. If the letter is correct but in an incorrect location, mark it
-	//    yellow UNLESS the same letter is also provided in the correct location.
-	// 3. No letter should be marked yellow or green more times than it occurs
-	//    in the secret word.
-	// 4. Remaining unmarked letters must be marked grey.
+	// Rules for scoring (the official two-pass approach):
+	// 1. Mark every letter in the correct location green, and count the
+	//    secret letters that remain unmatched.
+	// 2. Mark each other letter yellow while an unmatched instance of it
+	//    remains in the secret, using up that instance.
+	// 3. Remaining unmarked letters are marked grey.
 	//
-	for i := 0; i < config.CONFIG_GAME_WORDLENGTH; i++ {
-		if g.SecretWord[i] == byte(tryWord[i]) {
+	// Letters are compared as runes so accented letters score as one letter.
+	secret, try := []rune(g.SecretWord), []rune(tryWord)
+	length := len(secret)
+	if len(try) != length || len(score) != length {
+		return ErrWordLength
+	}
+
+	remaining := map[rune]int{}
+	for i := 0; i < length; i++ {
+		if secret[i] == try[i] {
 			score[i] = Green // exact match
+		} else {
+			score[i] = Blank
+			remaining[secret[i]]++
+		}
+	}
+	for i := 0; i < length; i++ {
+		if score[i] == Green {
+			continue
+		}
+		if remaining[try[i]] > 0 {
+			score[i] = Yellow
+			remaining[try[i]]--
 			continue
-		} else if count := strings.Count(g.SecretWord, string(tryWord[i])); count > 0 {
-			// Letter is definitely in the secret word. Check if there are other instances of the
-			// same letter that are or will be marked green or yellow elsewhere in the word.
-			if countLeft := strings.Count(g.SecretWord[:i+1], string(tryWord[i])); countLeft > 0 {
-				// If letter occured fewer times in tryWord than secret, mark is yellow
-				if strings.Count(tryWord[:i+1], string(tryWord[i])) <= countLeft {
-					score[i] = Yellow
-					continue
-				}
-			}
-			if countRight := strings.Count(g.SecretWord[i:], string(tryWord[i])); countRight > 0 {
-				if strings.Count(tryWord[i:], string(tryWord[i])) <= countRight {
-					score[i] = Yellow
-					continue
-				}
-			}
 		}
 		score[i] = Grey
 	}
@@ -2,10 +2,19 @@ package game
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/dictionary"
+	"aluance.io/wordleserver/internal/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -43,6 +52,79 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestPlayErrors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+
+	_, err = game.Play("happyday")
+	assert.ErrorIs(err, ErrWordLength)
+	_, err = game.Play("zzzzz")
+	assert.ErrorIs(err, ErrInvalidWord)
+	_, err = game.Play("happy")
+	assert.NoError(err)
+	_, err = game.Play("happy")
+	assert.ErrorIs(err, ErrGameOver)
+
+	// Running out of turns on invalid words loses the game
+	game, err = Create("happy")
+	require.NoError(err)
+	for i := 0; i < config.CONFIG_GAME_MAXATTEMPTS; i++ {
+		_, err = game.Play("zzzzz")
+		assert.ErrorIs(err, ErrInvalidWord, i)
+	}
+	assert.Equal(Lost, game.(*wordleGame).GameStatus)
+	_, err = game.Play("happy")
+	assert.ErrorIs(err, ErrGameOver)
+
+	// A game already out of turns is lost on the next play
+	game, err = Create("happy")
+	require.NoError(err)
+	v := game.(*wordleGame)
+	v.ValidAttempts = config.CONFIG_GAME_MAXVALIDATTEMPTS
+	_, err = game.Play("heave")
+	assert.ErrorIs(err, ErrOutOfTurns)
+	assert.Equal(Lost, v.GameStatus)
+
+	_, err = Create("happyday")
+	assert.ErrorIs(err, ErrWordLength)
+	_, err = Create("qzxvw")
+	assert.ErrorIs(err, ErrInvalidWord)
+}
+
+func TestCreateDictionarySecret(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		secretWord string
+		opts       GameOptions
+		err        error
+	}{
+		{secretWord: "happy", opts: GameOptions{}},
+		{secretWord: "qzxvw", opts: GameOptions{}, err: ErrInvalidWord},
+		{secretWord: "12345", opts: GameOptions{}, err: ErrInvalidWord},
+		{secretWord: "qzxvw", opts: GameOptions{AllowAnySecret: true}},
+		{secretWord: "qzxv", opts: GameOptions{AllowAnySecret: true}, err: ErrWordLength},
+	}
+
+	for _, test := range tests {
+		g, err := CreateWithOptions(test.secretWord, test.opts)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, test.secretWord)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err, test.secretWord)
+
+		// The secret can always be guessed, even when not a dictionary word
+		_, err = g.Play(test.secretWord)
+		require.NoError(err, test.secretWord)
+		assert.Equal(Won, g.(*wordleGame).GameStatus, test.secretWord)
+	}
+}
+
 func TestDescribe(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -73,6 +155,46 @@ func TestDescribe(t *testing.T) {
 	}
 }
 
+func TestDescribePublic(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	describe := func(g Game) map[string]interface{} {
+		s, err := g.DescribePublic()
+		require.NoError(err)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		return out
+	}
+
+	game, err := CreateWithOptions("happy", GameOptions{MaxAttempts: 2})
+	require.NoError(err)
+	require.NoError(game.SetNote("try heave first"))
+
+	// The secret and the note are hidden while in play
+	_, err = game.Play("heave")
+	require.NoError(err)
+	out := describe(game)
+	assert.NotContains(out, "secretWord")
+	assert.NotContains(out, "note")
+	assert.Contains(out, "attempts")
+	assert.EqualValues("InPlay", out["gameStatus"])
+
+	// The secret is shown once the game is lost, but the note never is
+	_, err = game.Play("bless")
+	require.NoError(err)
+	out = describe(game)
+	assert.EqualValues("Lost", out["gameStatus"])
+	assert.Equal("HAPPY", out["secretWord"])
+	assert.NotContains(out, "note")
+
+	// Describe still includes the note
+	s, err := game.Describe()
+	require.NoError(err)
+	assert.Contains(s, "try heave first")
+	assert.Equal("try heave first", game.Note())
+}
+
 func TestPlay(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -86,10 +208,10 @@ func TestPlay(t *testing.T) {
 		err         error
 	}{
 		{createWord: "happy", tryWord: "", err: ErrWordLength},
-		{createWord: "happy", tryWord: "zzzzz", gameMap: map[string]interface{}{"attemptsUsed": 0, "gameStatus": "InPlay"}, lastAttempt: map[string]interface{}{"isValidWord": false, "tryWord": "ZZZZZ"}, tryResult: []LetterHint{3, 3, 3, 3, 3}, err: ErrInvalidWord},
-		{createWord: "happy", tryWord: "happy", gameMap: map[string]interface{}{"attemptsUsed": 1, "gameStatus": "Won", "secretWord": "HAPPY", "winningAttempt": 1}, lastAttempt: map[string]interface{}{"isValidWord": true, "tryWord": "HAPPY"}, tryResult: []LetterHint{1, 1, 1, 1, 1}, err: nil},
-		{createWord: "happy", tryWord: "puppy", gameMap: map[string]interface{}{"attemptsUsed": 1, "gameStatus": "InPlay"}, lastAttempt: map[string]interface{}{"isValidWord": true, "tryWord": "PUPPY"}, tryResult: []LetterHint{3, 3, 1, 1, 1}, err: nil},
-		{createWord: "happy", tryWord: "bless", gameMap: map[string]interface{}{"attemptsUsed": 1, "gameStatus": "InPlay"}, lastAttempt: map[string]interface{}{"isValidWord": true, "tryWord": "BLESS"}, tryResult: []LetterHint{3, 3, 3, 3, 3}, err: nil},
+		{createWord: "happy", tryWord: "zzzzz", gameMap: map[string]interface{}{"attemptsUsed": 0, "gameStatus": "InPlay"}, lastAttempt: map[string]interface{}{"isValidWord": false, "tryWord": "zzzzz"}, tryResult: []LetterHint{3, 3, 3, 3, 3}, err: ErrInvalidWord},
+		{createWord: "happy", tryWord: "happy", gameMap: map[string]interface{}{"attemptsUsed": 1, "gameStatus": "Won", "secretWord": "HAPPY", "winningAttempt": 1}, lastAttempt: map[string]interface{}{"isValidWord": true, "tryWord": "happy"}, tryResult: []LetterHint{1, 1, 1, 1, 1}, err: nil},
+		{createWord: "happy", tryWord: "puppy", gameMap: map[string]interface{}{"attemptsUsed": 1, "gameStatus": "InPlay"}, lastAttempt: map[string]interface{}{"isValidWord": true, "tryWord": "puppy"}, tryResult: []LetterHint{3, 3, 1, 1, 1}, err: nil},
+		{createWord: "happy", tryWord: "bless", gameMap: map[string]interface{}{"attemptsUsed": 1, "gameStatus": "InPlay"}, lastAttempt: map[string]interface{}{"isValidWord": true, "tryWord": "bless"}, tryResult: []LetterHint{3, 3, 3, 3, 3}, err: nil},
 	}
 
 	for _, test := range tests {
@@ -131,6 +253,23 @@ func TestPlay(t *testing.T) {
 	}
 }
 
+func TestErrorAliases(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := Create("abc")
+	assert.True(errors.Is(err, ErrWrongLength))
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	_, err = game.Play("abc")
+	assert.True(errors.Is(err, ErrWrongLength))
+	_, err = game.Play("happy")
+	require.NoError(err)
+	_, err = game.Play("bless")
+	assert.True(errors.Is(err, ErrGameFinished))
+}
+
 func TestResign(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -165,6 +304,88 @@ func TestResign(t *testing.T) {
 			assert.EqualValues(v, out[k])
 		}
 	}
+
+	// A finished game can't be resigned
+	game, err := Create("bless")
+	require.NoError(err)
+	_, err = game.Play("bless")
+	require.NoError(err)
+	_, err = game.Resign()
+	assert.ErrorIs(err, ErrGameOver)
+	assert.Equal(Won, game.(*wordleGame).GameStatus)
+}
+
+func TestResignGracePeriod(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	status := func(s string) interface{} {
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		return out["gameStatus"]
+	}
+
+	// Playing within the window cancels the resignation
+	game, err := CreateWithOptions("happy", GameOptions{ResignGracePeriod: time.Hour})
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+	s, err := game.Resign()
+	require.NoError(err)
+	assert.Equal("InPlay", status(s))
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+	assert.NotNil(v.ResignPendingUntil)
+	_, err = game.Play("bless")
+	require.NoError(err)
+	assert.Nil(v.ResignPendingUntil)
+	s, err = game.Play("happy")
+	require.NoError(err)
+	assert.Equal("Won", status(s))
+
+	// Cancelling and confirming act on a pending resignation only
+	game, err = CreateWithOptions("happy", GameOptions{ResignGracePeriod: time.Hour})
+	require.NoError(err)
+	_, err = game.CancelResign()
+	assert.ErrorIs(err, ErrNoPendingResign)
+	game.Resign()
+	s, err = game.CancelResign()
+	require.NoError(err)
+	assert.Equal("InPlay", status(s))
+	game.Resign()
+	s, err = game.ConfirmResign()
+	require.NoError(err)
+	assert.Equal("Resigned", status(s))
+	_, err = game.ConfirmResign()
+	assert.ErrorIs(err, ErrNoPendingResign)
+
+	// Once the window lapses the resignation is final
+	game, err = CreateWithOptions("happy", GameOptions{ResignGracePeriod: 10 * time.Millisecond})
+	require.NoError(err)
+	game.Resign()
+	time.Sleep(20 * time.Millisecond)
+	r, err := Retrieve(game.(*wordleGame).Id)
+	require.NoError(err)
+	s, err = r.Describe()
+	require.NoError(err)
+	assert.Equal("Resigned", status(s))
+	_, err = game.Play("bless")
+	assert.ErrorIs(err, ErrGameOver)
+	_, err = game.CancelResign()
+	assert.ErrorIs(err, ErrNoPendingResign)
+
+	// Without a grace period resigning is immediate
+	game, err = Create("happy")
+	require.NoError(err)
+	s, err = game.Resign()
+	require.NoError(err)
+	assert.Equal("Resigned", status(s))
+}
+
+// Marks the game as resigned with a grace period that has already lapsed,
+// as a stored game is until it is next retrieved
+func lapseResign(game Game) Game {
+	until := now().Add(-time.Minute)
+	game.(*wordleGame).ResignPendingUntil = &until
+	return game
 }
 
 func TestAddAttempt(t *testing.T) {
@@ -204,6 +425,18 @@ func TestRetrieve(t *testing.T) {
 		{createWord: "seven", id: "", err: nil},
 	}
 
+	// A missing game and content that isn't a game fail differently
+	gs, err := store.WordleStore()
+	require.NoError(err)
+	require.NoError(gs.Save("corrupt0001", "not a game"))
+	defer gs.Delete("corrupt0001")
+
+	for id, want := range map[string]error{"missing0001": ErrGameNotFound, "corrupt0001": ErrSerialization} {
+		game, err := Retrieve(id)
+		assert.ErrorIs(err, want, id)
+		assert.Nil(game, id)
+	}
+
 	// Create test games
 	for i, test := range tests {
 		game, err := Create(test.createWord)
@@ -233,6 +466,232 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
+func TestPlayConcurrent(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	fs, err := store.FileStore(t.TempDir())
+	require.NoError(err)
+	defer store.SetStore(fs)()
+
+	game, err := Create("happy")
+	require.NoError(err)
+	id := game.(*wordleGame).Id
+
+	// Each play retrieves its own copy of the game, as separate requests would
+	guesses := []string{"heave", "handy", "hairy", "harry", "hardy"}
+	var wg sync.WaitGroup
+	for _, w := range guesses {
+		wg.Add(1)
+		go func(w string) {
+			defer wg.Done()
+			g, err := Retrieve(id)
+			if assert.NoError(err) {
+				_, err = g.Play(w)
+				assert.NoError(err, w)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	r, err := Retrieve(id)
+	require.NoError(err)
+	v := r.(*wordleGame)
+	assert.Equal(len(guesses), v.ValidAttempts)
+	played := []string{}
+	for _, a := range v.Attempts {
+		played = append(played, strings.ToLower(a.TryWord))
+	}
+	assert.ElementsMatch(guesses, played)
+
+	// Resigning is also applied to the stored state, not the stale copy
+	_, err = game.Resign()
+	require.NoError(err)
+	assert.Equal(len(guesses), game.(*wordleGame).ValidAttempts)
+	r, err = Retrieve(id)
+	require.NoError(err)
+	assert.Equal(Resigned, r.(*wordleGame).GameStatus)
+	assert.Len(r.(*wordleGame).Attempts, len(guesses))
+}
+
+func TestRetrieveRegisteredStore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Games are decoded with the codec of the store
+	for _, codec := range []store.Codec{store.JSONCodec, store.GobCodec} {
+		fs, err := store.FileStore(t.TempDir(), store.WithCodec(codec))
+		require.NoError(err)
+		restore := store.SetStore(fs)
+
+		game, err := Create("happy")
+		require.NoError(err)
+		_, err = game.Play("heave")
+		require.NoError(err)
+		v := game.(*wordleGame)
+
+		// The game is persisted, and plays on after being retrieved
+		r, err := Retrieve(v.Id)
+		require.NoError(err)
+		_, err = r.Play("happy")
+		require.NoError(err)
+
+		r, err = Retrieve(v.Id)
+		require.NoError(err)
+		assert.Equal(Won, r.(*wordleGame).GameStatus)
+		assert.Equal(2, r.(*wordleGame).ValidAttempts)
+
+		code, err := r.ShareCode()
+		require.NoError(err)
+		r, err = ResolveShareCode(code)
+		require.NoError(err)
+		assert.Equal(v.Id, r.(*wordleGame).Id)
+
+		restore()
+	}
+}
+
+func TestUpdateStoredState(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := store.WordleStore()
+	require.NoError(err)
+
+	// A deleted game isn't brought back by playing it
+	game, err := Create("happy")
+	require.NoError(err)
+	id := game.(*wordleGame).Id
+	require.NoError(s.Delete(id))
+	_, err = game.Play("heave")
+	assert.ErrorIs(err, ErrGameNotFound)
+	_, err = game.Resign()
+	assert.ErrorIs(err, ErrGameNotFound)
+	ok, err := s.Exists(id)
+	require.NoError(err)
+	assert.False(ok)
+
+	// A game stored as a JSON string plays on
+	game, err = Create("happy")
+	require.NoError(err)
+	id = game.(*wordleGame).Id
+	b, err := json.Marshal(game)
+	require.NoError(err)
+	require.NoError(s.Save(id, string(b)))
+	r, err := Retrieve(id)
+	require.NoError(err)
+	_, err = r.Play("heave")
+	require.NoError(err)
+
+	// Notes and share codes set on a stale copy keep attempts played since
+	fs, err := store.FileStore(t.TempDir())
+	require.NoError(err)
+	defer store.SetStore(fs)()
+	game, err = Create("happy")
+	require.NoError(err)
+	id = game.(*wordleGame).Id
+	r, err = Retrieve(id)
+	require.NoError(err)
+	_, err = r.Play("heave")
+	require.NoError(err)
+	require.NoError(game.SetNote("a note"))
+	_, err = game.ShareCode()
+	require.NoError(err)
+	r, err = Retrieve(id)
+	require.NoError(err)
+	v := r.(*wordleGame)
+	assert.Len(v.Attempts, 1)
+	assert.Equal("a note", v.PlayerNote)
+	assert.NotEmpty(v.ShareKey)
+}
+
+// Runs onLoad once after the first Load, as a request landing between a load
+// and a save would
+type interleavingStore struct {
+	store.Store
+	onLoad func()
+}
+
+func (s *interleavingStore) Load(id string) (interface{}, error) {
+	content, err := s.Store.Load(id)
+	if fn := s.onLoad; fn != nil {
+		s.onLoad = nil
+		fn()
+	}
+
+	return content, err
+}
+
+func TestRetrieveSettlesStoredState(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer ResetClock()
+
+	fs, err := store.FileStore(t.TempDir())
+	require.NoError(err)
+	is := &interleavingStore{Store: fs}
+	defer store.SetStore(is)()
+
+	game, err := CreateWithOptions("happy", GameOptions{ResignGracePeriod: time.Minute})
+	require.NoError(err)
+	id := game.(*wordleGame).Id
+	_, err = game.Resign()
+	require.NoError(err)
+
+	// A note set while the lapsed resignation is being settled is kept
+	now = now.Add(2 * time.Minute)
+	is.onLoad = func() { require.NoError(game.SetNote("a note")) }
+	r, err := Retrieve(id)
+	require.NoError(err)
+	assert.Equal(Resigned, r.(*wordleGame).GameStatus)
+
+	r, err = Retrieve(id)
+	require.NoError(err)
+	v := r.(*wordleGame)
+	assert.Equal(Resigned, v.GameStatus)
+	assert.Equal("a note", v.PlayerNote)
+}
+
+func TestGameTTL(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Games and the store share one clock, so expiry matches the game times
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	SetClock(clock)
+	defer ResetClock()
+	store.SetClock(clock)
+	defer store.SetClock(nil)
+
+	game, err := CreateWithOptions("happy", GameOptions{TTL: time.Hour})
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+	id := game.(*wordleGame).Id
+
+	// Playing keeps the TTL, and the game can't be played once it expires
+	now = now.Add(30 * time.Minute)
+	_, err = game.Play("heave")
+	require.NoError(err)
+	now = now.Add(29 * time.Minute)
+	_, err = Retrieve(id)
+	require.NoError(err)
+	now = now.Add(time.Minute)
+	_, err = Retrieve(id)
+	assert.ErrorIs(err, ErrGameNotFound)
+	_, err = game.Play("happy")
+	assert.ErrorIs(err, ErrGameNotFound)
+
+	// Games without the option don't expire
+	game, err = Create("happy")
+	require.NoError(err)
+	now = now.Add(24 * time.Hour)
+	_, err = Retrieve(game.(*wordleGame).Id)
+	assert.NoError(err)
+}
+
 func TestScoreWord(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -251,11 +710,31 @@ func TestScoreWord(t *testing.T) {
 		{createWord: "knoll", tryWord: "wooly", result: []LetterHint{Grey, Grey, Green, Green, Grey}, err: nil},
 		{createWord: "paths", tryWord: "saved", result: []LetterHint{Yellow, Green, Grey, Grey, Grey}, err: nil},
 		{createWord: "ankle", tryWord: "abate", result: []LetterHint{Green, Grey, Grey, Grey, Green}, err: nil},
+
+		// Letters matched against the last position of the secret
+		{createWord: "abbey", tryWord: "kayak", result: []LetterHint{Grey, Yellow, Yellow, Grey, Grey}, err: nil},
+		{createWord: "abbey", tryWord: "yeast", result: []LetterHint{Yellow, Yellow, Yellow, Grey, Grey}, err: nil},
+		{createWord: "happy", tryWord: "mummy", result: []LetterHint{Grey, Grey, Grey, Grey, Green}, err: nil},
+		{createWord: "adieu", tryWord: "ulcer", result: []LetterHint{Yellow, Grey, Grey, Green, Grey}, err: nil},
+		{createWord: "night", tryWord: "thing", result: []LetterHint{Yellow, Yellow, Yellow, Yellow, Yellow}, err: nil},
+		{createWord: "sugar", tryWord: "rusty", result: []LetterHint{Yellow, Green, Yellow, Grey, Grey}, err: nil},
+
+		// Repeated letters are marked no more often than they occur in the secret
+		{createWord: "allot", tryWord: "lolly", result: []LetterHint{Yellow, Yellow, Green, Grey, Grey}, err: nil},
+		{createWord: "scent", tryWord: "tease", result: []LetterHint{Yellow, Yellow, Grey, Yellow, Grey}, err: nil},
+		{createWord: "scent", tryWord: "tents", result: []LetterHint{Yellow, Yellow, Yellow, Grey, Yellow}, err: nil},
+		{createWord: "sugar", tryWord: "roars", result: []LetterHint{Yellow, Grey, Yellow, Grey, Yellow}, err: nil},
+		{createWord: "abbey", tryWord: "babes", result: []LetterHint{Yellow, Yellow, Green, Green, Grey}, err: nil},
+		{createWord: "robot", tryWord: "ooooo", result: []LetterHint{Grey, Green, Grey, Green, Grey}, err: nil},
+		{createWord: "eerie", tryWord: "there", result: []LetterHint{Grey, Grey, Yellow, Yellow, Green}, err: nil},
+		{createWord: "geese", tryWord: "eerie", result: []LetterHint{Yellow, Green, Grey, Grey, Green}, err: nil},
+		{createWord: "mamma", tryWord: "amass", result: []LetterHint{Yellow, Yellow, Yellow, Grey, Grey}, err: nil},
+		{createWord: "llama", tryWord: "hello", result: []LetterHint{Grey, Grey, Yellow, Yellow, Grey}, err: nil},
 	}
 
 	for _, test := range tests {
-		// Create test game
-		game, err := Create(test.createWord)
+		// Create test game, allowing secrets outside the dictionary
+		game, err := CreateWithOptions(test.createWord, GameOptions{AllowAnySecret: true})
 		require.NoError(err, "Create() returned error when creating Game")
 		require.NotNil(game, "unable to create a Game object")
 
@@ -273,3 +752,1132 @@ func TestScoreWord(t *testing.T) {
 	}
 
 }
+
+func TestScoreWordRunes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// A five letter dictionary of accented words, longer than five bytes
+	words := []string{"rêver", "crème", "fêtée", "éclat", "brûlé"}
+	for _, w := range words {
+		require.NoError(dictionary.AddWord(w))
+		defer dictionary.RemoveWord(w)
+	}
+
+	tests := []struct {
+		createWord string
+		tryWord    string
+		result     []LetterHint
+	}{
+		{createWord: "rêver", tryWord: "crème", result: []LetterHint{Grey, Yellow, Grey, Grey, Yellow}},
+		{createWord: "fêtée", tryWord: "éclat", result: []LetterHint{Yellow, Grey, Grey, Grey, Yellow}},
+		{createWord: "brûlé", tryWord: "éclat", result: []LetterHint{Yellow, Grey, Yellow, Grey, Grey}},
+		{createWord: "crème", tryWord: "crème", result: []LetterHint{Green, Green, Green, Green, Green}},
+	}
+
+	for _, test := range tests {
+		game, err := Create(test.createWord)
+		require.NoError(err, test.createWord)
+		v := game.(*wordleGame)
+
+		_, err = game.Play(test.tryWord)
+		require.NoError(err, test.tryWord)
+		require.Len(v.Attempts, 1)
+		assert.Exactly(test.result, v.Attempts[0].TryResult, test.tryWord)
+	}
+
+	// Lengths count letters, so six bytes can be five letters and not six
+	game, err := Create("rêver")
+	require.NoError(err)
+	_, err = game.Play("crèmes")
+	assert.ErrorIs(err, ErrWordLength)
+	_, err = game.Play("RÊVER")
+	require.NoError(err)
+	assert.Equal(Won, game.(*wordleGame).GameStatus)
+
+	hint, err := CreateWithOptions("éclat", GameOptions{})
+	require.NoError(err)
+	reveal, err := hint.Hint()
+	require.NoError(err)
+	assert.JSONEq(`{"position":0,"letter":"É"}`, reveal)
+
+	// Sampling guesses with accented letters scores them by letter
+	traps, err := TrapLetters("crème")
+	require.NoError(err)
+	for _, r := range traps {
+		assert.True(strings.ContainsRune("CRÈME", r), string(r))
+	}
+}
+
+func TestCreateSeeded(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g1, err := CreateSeeded(2022)
+	require.NoError(err)
+	g2, err := CreateSeeded(2022)
+	require.NoError(err)
+
+	v1 := g1.(*wordleGame)
+	v2 := g2.(*wordleGame)
+	assert.NotEqual(v1.Id, v2.Id)
+	assert.Equal(v1.SecretWord, v2.SecretWord)
+	assert.True(dictionary.IsWordValid(v1.SecretWord))
+
+	w, err := dictionary.GenerateWordSeeded(2022)
+	require.NoError(err)
+	assert.Equal(strings.ToUpper(w), v1.SecretWord)
+}
+
+func TestCreateDaily(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	defer func(e time.Time) { config.CONFIG_DAILY_EPOCH = e }(config.CONFIG_DAILY_EPOCH)
+	config.CONFIG_DAILY_EPOCH = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		date   time.Time
+		number int
+		err    error
+	}{
+		{date: time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC), number: 0},
+		{date: time.Date(2022, time.January, 2, 9, 0, 0, 0, time.UTC), number: 1},
+		{date: time.Date(2021, time.December, 31, 9, 0, 0, 0, time.UTC), err: dictionary.ErrBeforeEpoch},
+	}
+
+	for _, test := range tests {
+		g1, err := CreateDaily(test.date)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err)
+		g2, err := CreateDaily(test.date)
+		require.NoError(err)
+
+		v1, ok := g1.(*wordleGame)
+		require.True(ok)
+		v2, ok := g2.(*wordleGame)
+		require.True(ok)
+		assert.Equal(v1.SecretWord, v2.SecretWord, "daily games should share the secret")
+		assert.NotEqual(v1.Id, v2.Id)
+		assert.Equal(test.number, v1.PuzzleNumber)
+
+		// The puzzle number is shown in the report and the share grid
+		s, err := g1.Describe()
+		require.NoError(err)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		assert.EqualValues(test.number, out["puzzleNumber"])
+
+		_, err = g1.Resign()
+		require.NoError(err)
+		share, err := g1.ShareGrid()
+		require.NoError(err)
+		assert.True(strings.HasPrefix(share, fmt.Sprintf("Wordle %d X/6", test.number)), share)
+	}
+
+	// Non-daily games don't report a puzzle number
+	g, err := Create("happy")
+	require.NoError(err)
+	s, err := g.Describe()
+	require.NoError(err)
+	assert.NotContains(s, "puzzleNumber")
+}
+
+func TestTransitions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		tryWords   []string
+		resign     bool
+		result     []GameStatusType
+	}{
+		{createWord: "happy", result: []GameStatusType{}},
+		{createWord: "happy", tryWords: []string{"puppy", "happy"}, result: []GameStatusType{InPlay, Won}},
+		{createWord: "happy", tryWords: []string{"puppy"}, resign: true, result: []GameStatusType{InPlay, Resigned}},
+	}
+
+	for _, test := range tests {
+		start := time.Now()
+		game, err := Create(test.createWord)
+		require.NoError(err, "Create() returned error when creating Game")
+
+		for _, tw := range test.tryWords {
+			_, err := game.Play(tw)
+			require.NoError(err)
+		}
+		if test.resign {
+			_, err := game.Resign()
+			require.NoError(err)
+		}
+
+		log := game.Transitions()
+		if len(test.result) < 1 {
+			assert.Empty(log)
+			continue
+		}
+		require.Len(log, len(test.result)-1)
+		for i, tr := range log {
+			assert.Equal(test.result[i], tr.From)
+			assert.Equal(test.result[i+1], tr.To)
+			assert.False(tr.At.Before(start))
+			assert.False(tr.At.After(time.Now()))
+		}
+	}
+}
+
+func TestWinType(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		tryWords   []string
+		result     WinType
+	}{
+		{createWord: "happy", tryWords: []string{"happy"}, result: FirstTry},
+		{createWord: "happy", tryWords: []string{"puppy", "bless", "happy"}, result: Normal},
+		{createWord: "happy", tryWords: []string{"puppy", "bless", "grand", "smile", "poems", "happy"}, result: Clutch},
+		{createWord: "happy", tryWords: []string{"puppy"}, result: NoWin},
+	}
+
+	for _, test := range tests {
+		game, err := Create(test.createWord)
+		require.NoError(err, "Create() returned error when creating Game")
+
+		var s string
+		for _, tw := range test.tryWords {
+			s, err = game.Play(tw)
+			require.NoError(err, tw)
+		}
+
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+		assert.Equal(test.result, v.winType(), strings.Join(test.tryWords, ","))
+
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		if test.result == NoWin {
+			assert.NotContains(out, "winType")
+			continue
+		}
+		assert.Equal(test.result.String(), out["winType"])
+	}
+}
+
+func TestClone(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	_, err = game.Play("puppy")
+	require.NoError(err)
+
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+	c, ok := v.Clone().(*wordleGame)
+	require.True(ok)
+	assert.Equal(v, c)
+
+	// Changes to the original don't leak into the copy
+	_, err = game.Play("happy")
+	require.NoError(err)
+	v.Attempts[0].TryResult[0] = Blank
+	assert.Equal(InPlay, c.GameStatus)
+	assert.Len(c.Attempts, 1)
+	assert.Equal(Grey, c.Attempts[0].TryResult[0])
+	assert.Empty(c.StatusLog)
+
+	// Rejected guesses aren't shared either
+	game, err = CreateWithOptions("happy", GameOptions{RecordRejected: true})
+	require.NoError(err)
+	game.Play("zzzzz")
+	v = game.(*wordleGame)
+	c = v.Clone().(*wordleGame)
+	assert.Equal(v.RejectedGuesses, c.RejectedGuesses)
+	v.RejectedGuesses[0] = "XXXXX"
+	game.Play("qqqqq")
+	assert.Equal([]string{"ZZZZZ"}, c.RejectedGuesses)
+	c.RejectedGuesses = append(c.RejectedGuesses, "YYYYY")
+	assert.Equal([]string{"XXXXX", "QQQQQ"}, v.RejectedGuesses)
+}
+
+func TestWinOnLastAttempt(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		lastWord   string
+		status     string
+	}{
+		{createWord: "happy", lastWord: "happy", status: "Won"},
+		{createWord: "happy", lastWord: "scent", status: "Lost"},
+	}
+	wrongWords := []string{"puppy", "bless", "grand", "smile", "poems"}
+
+	for _, test := range tests {
+		game, err := Create(test.createWord)
+		require.NoError(err, "Create() returned error when creating Game")
+
+		for _, tw := range wrongWords {
+			_, err := game.Play(tw)
+			require.NoError(err, tw)
+		}
+		s, err := game.Play(test.lastWord)
+		require.NoError(err)
+
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		assert.Equal(test.status, out["gameStatus"], test.lastWord)
+		assert.EqualValues(config.CONFIG_GAME_MAXVALIDATTEMPTS, out["attemptsUsed"])
+		if test.status == "Won" {
+			assert.EqualValues(config.CONFIG_GAME_MAXVALIDATTEMPTS, out["winningAttempt"])
+		} else {
+			assert.NotContains(out, "winningAttempt")
+		}
+	}
+}
+
+func TestSetIDGenerator(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	defer SetIDGenerator(nil)
+
+	count := 0
+	SetIDGenerator(func() string {
+		count++
+		return fmt.Sprintf("game-%d", count)
+	})
+
+	for i := 1; i <= 3; i++ {
+		game, err := Create("happy")
+		require.NoError(err, "Create() returned error when creating Game")
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+		assert.Equal(fmt.Sprintf("game-%d", i), v.Id)
+
+		r, err := Retrieve(v.Id)
+		assert.NoError(err)
+		assert.Equal(game, r)
+	}
+
+	// Generated IDs must be valid
+	SetIDGenerator(func() string { return "" })
+	_, err := Create("happy")
+	assert.ErrorIs(err, store.ErrInvalidId)
+
+	// The default generator is restored with nil
+	SetIDGenerator(nil)
+	game, err := Create("happy")
+	require.NoError(err)
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+	assert.NotContains(v.Id, "game-")
+}
+
+func TestRejectedGuesses(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		opts     GameOptions
+		attempts int
+		rejected []string
+	}{
+		{opts: GameOptions{}, attempts: 4, rejected: nil},
+		{opts: GameOptions{RecordRejected: true}, attempts: 2, rejected: []string{"ZZZZZ", "abc"}},
+	}
+
+	for _, test := range tests {
+		game, err := CreateWithOptions("happy", test.opts)
+		require.NoError(err, "CreateWithOptions() returned error when creating Game")
+
+		game.Play("puppy")
+		_, err = game.Play("zzzzz")
+		assert.ErrorIs(err, ErrInvalidWord)
+		_, err = game.Play("abc")
+		assert.ErrorIs(err, ErrWordLength)
+		s, err := game.Play("bless")
+		require.NoError(err)
+
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+		assert.Len(v.Attempts, test.attempts)
+		assert.Equal(2, v.ValidAttempts)
+		assert.Equal(test.rejected, v.RejectedGuesses)
+
+		// Rejected guesses are only exposed in the debug report
+		assert.NotContains(s, "rejectedGuesses")
+		d, err := game.DebugReport()
+		require.NoError(err)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(d), &out))
+		if test.rejected == nil {
+			assert.NotContains(out, "rejectedGuesses")
+			continue
+		}
+		assert.ElementsMatch([]interface{}{"ZZZZZ", "abc"}, out["rejectedGuesses"])
+	}
+}
+
+func TestSkipInvalidGuesses(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := CreateWithOptions("happy", GameOptions{SkipInvalidGuesses: true})
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+
+	// More invalid guesses than the game allows attempts
+	for i := 0; i < config.CONFIG_GAME_MAXATTEMPTS+3; i++ {
+		s, err := game.Play("zzzzz")
+		assert.ErrorIs(err, ErrInvalidWord)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		require.Contains(out, "rejectedGuess")
+		rejected := out["rejectedGuess"].(map[string]interface{})
+		assert.Equal("zzzzz", rejected["tryWord"])
+		assert.Equal(false, rejected["isValidWord"])
+		assert.Empty(out["attempts"])
+		assert.EqualValues(config.CONFIG_GAME_MAXVALIDATTEMPTS, out["attemptsRemaining"])
+	}
+	_, err = game.Play("abc")
+	assert.ErrorIs(err, ErrWordLength)
+
+	s, err := game.Play("puppy")
+	require.NoError(err)
+	assert.NotContains(s, "rejectedGuess")
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.EqualValues(config.CONFIG_GAME_MAXVALIDATTEMPTS-1, out["attemptsRemaining"])
+	assert.Len(out["attempts"], 1)
+
+	// The stored game holds only the valid attempt
+	stored, err := Retrieve(game.(*wordleGame).Id)
+	require.NoError(err)
+	v, ok := stored.(*wordleGame)
+	require.True(ok)
+	assert.Len(v.Attempts, 1)
+	assert.Equal(1, v.ValidAttempts)
+	assert.Empty(v.RejectedGuesses)
+}
+
+func TestHintMatrix(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+
+	m, err := game.HintMatrix()
+	assert.NoError(err)
+	assert.Empty(m)
+
+	game.Play("puppy")
+	game.Play("zzzzz")
+	game.Play("happy")
+
+	m, err = game.HintMatrix()
+	assert.NoError(err)
+	tests := [][]LetterHint{
+		{Grey, Grey, Green, Green, Green},
+		{Blank, Blank, Blank, Blank, Blank},
+		{Green, Green, Green, Green, Green},
+	}
+	require.Len(m, len(tests))
+	for i, row := range tests {
+		assert.Len(m[i], config.CONFIG_GAME_WORDLENGTH)
+		assert.Equal(row, m[i])
+	}
+
+	// The matrix is a copy of the game state
+	m[0][0] = Red
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+	assert.Equal(Grey, v.Attempts[0].TryResult[0])
+}
+
+func TestWordLength(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		length  int
+		secret  string
+		guesses map[string]error
+		err     error
+	}{
+		{length: 0, secret: "happy", guesses: map[string]error{"bless": nil, "bear": ErrWordLength}},
+		{length: 4, secret: "pear", guesses: map[string]error{"bear": nil, "hope": nil, "zzzz": ErrInvalidWord, "happy": ErrWordLength}},
+		{length: 6, secret: "planet", guesses: map[string]error{"garden": nil, "plants": nil, "pear": ErrWordLength}},
+		{length: 4, secret: ""},
+		{length: 4, secret: "happy", err: ErrWordLength},
+	}
+
+	for _, test := range tests {
+		game, err := CreateWithOptions(test.secret, GameOptions{WordLength: test.length})
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err, "CreateWithOptions() returned error when creating Game")
+		length := test.length
+		if length < 1 {
+			length = config.CONFIG_GAME_WORDLENGTH
+		}
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+		assert.Len(v.SecretWord, length)
+
+		for tw, want := range test.guesses {
+			_, err := game.Play(tw)
+			if want != nil {
+				assert.ErrorIs(err, want, tw)
+			} else {
+				assert.NoError(err, tw)
+			}
+		}
+		for _, a := range v.Attempts {
+			assert.Len(a.TryResult, length, a.TryWord)
+		}
+
+		// The length is part of the report
+		s, err := game.Describe()
+		require.NoError(err)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		assert.EqualValues(length, out["wordLength"])
+
+		if len(test.secret) > 0 {
+			_, err = game.Play(test.secret)
+			assert.NoError(err)
+			assert.Equal(Won, v.GameStatus)
+		}
+	}
+}
+
+func TestMaxAttempts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	wrong := []string{"bless", "seven", "paint", "heave", "grand", "smile", "poems", "sugar"}
+
+	tests := []struct {
+		max    int
+		limit  int
+		header string
+	}{
+		{max: 0, limit: config.CONFIG_GAME_MAXVALIDATTEMPTS, header: fmt.Sprintf("Wordle X/%d", config.CONFIG_GAME_MAXVALIDATTEMPTS)},
+		{max: 3, limit: 3, header: "Wordle X/3"},
+		{max: 8, limit: 8, header: "Wordle X/8"},
+	}
+
+	for _, test := range tests {
+		game, err := CreateWithOptions("happy", GameOptions{MaxAttempts: test.max})
+		require.NoError(err, "CreateWithOptions() returned error when creating Game")
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+
+		for i := 0; i < test.limit; i++ {
+			assert.Equal(InPlay, v.GameStatus, i)
+			s, err := game.Play(wrong[i])
+			require.NoError(err, wrong[i])
+
+			out := map[string]interface{}{}
+			require.NoError(json.Unmarshal([]byte(s), &out))
+			assert.EqualValues(test.limit-i-1, out["attemptsRemaining"], wrong[i])
+		}
+
+		// The game is lost exactly at the limit
+		assert.Equal(Lost, v.GameStatus, test.max)
+		_, err = game.Play("happy")
+		assert.ErrorIs(err, ErrGameOver)
+		grid, err := game.ShareGrid()
+		require.NoError(err)
+		assert.True(strings.HasPrefix(grid, test.header), grid)
+	}
+
+	// A win on the last allowed attempt is a clutch win
+	game, err := CreateWithOptions("happy", GameOptions{MaxAttempts: 2})
+	require.NoError(err)
+	game.Play("bless")
+	game.Play("happy")
+	assert.Equal(Clutch, game.(*wordleGame).winType())
+}
+
+func TestRestart(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	opts := GameOptions{MaxAttempts: 3, HardMode: true}
+	game, err := CreateWithOptions("happy", opts)
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+	for _, w := range []string{"heave", "handy", "hairy"} {
+		game.Play(w)
+	}
+	v := game.(*wordleGame)
+	require.Equal(Lost, v.GameStatus)
+
+	r, err := game.Restart()
+	require.NoError(err)
+	n := r.(*wordleGame)
+	assert.NotEqual(v.Id, n.Id)
+	assert.Equal(v.SecretWord, n.SecretWord)
+	assert.Equal(InPlay, n.GameStatus)
+	assert.Empty(n.Attempts)
+	assert.Zero(n.ValidAttempts)
+	assert.Empty(n.StatusLog)
+	assert.Equal(opts, n.Options)
+	assert.Equal(3, n.RemainingAttempts())
+
+	// The original game is untouched, and both are stored
+	assert.Equal(Lost, v.GameStatus)
+	assert.Len(v.Attempts, 3)
+	for _, id := range []string{v.Id, n.Id} {
+		_, err := Retrieve(id)
+		assert.NoError(err, id)
+	}
+
+	_, err = r.Play("happy")
+	require.NoError(err)
+	assert.Equal(Won, n.GameStatus)
+}
+
+func TestGetAttempts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+
+	views, err := game.GetAttempts()
+	require.NoError(err)
+	assert.Empty(views)
+
+	for _, w := range []string{"heave", "zzzzz", "paint", "happy"} {
+		game.Play(w)
+	}
+
+	views, err = game.GetAttempts()
+	require.NoError(err)
+	require.Len(views, 4)
+
+	expected := []AttemptView{
+		{Index: 0, TryWord: "heave", Hints: []LetterHint{Green, Grey, Yellow, Grey, Grey}, IsValidWord: true},
+		{Index: 1, TryWord: "zzzzz", Hints: []LetterHint{Blank, Blank, Blank, Blank, Blank}, IsValidWord: false},
+		{Index: 2, TryWord: "paint", Hints: []LetterHint{Yellow, Green, Grey, Grey, Grey}, IsValidWord: true},
+		{Index: 3, TryWord: "happy", Hints: []LetterHint{Green, Green, Green, Green, Green}, IsValidWord: true, IsWinning: true},
+	}
+	assert.Equal(expected, views)
+
+	// The views are copies
+	views[0].Hints[0] = Blank
+	again, err := game.GetAttempts()
+	require.NoError(err)
+	assert.Equal(Green, again[0].Hints[0])
+
+	// No attempt wins a lost or resigned game
+	game, err = Create("happy")
+	require.NoError(err)
+	game.Play("heave")
+	game.Resign()
+	views, err = game.GetAttempts()
+	require.NoError(err)
+	require.Len(views, 1)
+	assert.False(views[0].IsWinning)
+}
+
+func TestRemainingAttempts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	remaining := func(s string) interface{} {
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+		return out["attemptsRemaining"]
+	}
+
+	// Each valid guess uses one attempt, down to zero on a loss
+	game, err := Create("happy")
+	require.NoError(err)
+	assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS, game.RemainingAttempts())
+	for i, w := range []string{"heave", "paint", "handy", "hairy", "harry", "hardy"} {
+		s, err := game.Play(w)
+		require.NoError(err, w)
+		assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS-i-1, game.RemainingAttempts(), w)
+		assert.EqualValues(game.RemainingAttempts(), remaining(s), w)
+	}
+	assert.Equal(Lost, game.(*wordleGame).GameStatus)
+	assert.Zero(game.RemainingAttempts())
+
+	// Invalid words are limited by the total attempts
+	game, err = Create("happy")
+	require.NoError(err)
+	invalid := config.CONFIG_GAME_MAXATTEMPTS - config.CONFIG_GAME_MAXVALIDATTEMPTS
+	for i := 0; i < invalid+2; i++ {
+		game.Play("zzzzz")
+	}
+	assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS-2, game.RemainingAttempts())
+
+	// A won or resigned game reports the guesses it didn't use
+	s, err := game.Play("happy")
+	require.NoError(err)
+	assert.Equal(Won, game.(*wordleGame).GameStatus)
+	assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS-3, game.RemainingAttempts())
+	assert.EqualValues(game.RemainingAttempts(), remaining(s))
+
+	game, err = Create("happy")
+	require.NoError(err)
+	game.Play("heave")
+	s, err = game.Resign()
+	require.NoError(err)
+	assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS-1, game.RemainingAttempts())
+	assert.EqualValues(game.RemainingAttempts(), remaining(s))
+}
+
+func TestAttemptTimings(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+
+	ts, err := game.AttemptTimings()
+	assert.NoError(err)
+	assert.Empty(ts)
+
+	start := time.Now()
+	tests := []string{"bless", "zzzzz", "puppy", "happy"}
+	for _, tw := range tests {
+		game.Play(tw)
+	}
+
+	ts, err = game.AttemptTimings()
+	assert.NoError(err)
+	require.Len(ts, len(tests))
+	for i, at := range ts {
+		assert.False(at.IsZero(), tests[i])
+		assert.False(at.Before(start.Add(-time.Second)), tests[i])
+		if i > 0 {
+			assert.False(at.Before(ts[i-1]), tests[i])
+		}
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	game.Play("bless")
+	game.Play("zzzzz")
+
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+	attrs := game.Attributes()
+	assert.Equal(map[string]string{
+		"game.id":                 v.Id,
+		"game.status":             "InPlay",
+		"game.attempts_used":      "2",
+		"game.max_attempts":       strconv.Itoa(config.CONFIG_GAME_MAXATTEMPTS),
+		"game.max_valid_attempts": strconv.Itoa(config.CONFIG_GAME_MAXVALIDATTEMPTS),
+		"game.word_length":        "5",
+		"game.mode":               "standard",
+		"game.hard_mode":          "false",
+	}, attrs)
+	for k, val := range attrs {
+		assert.NotContains(strings.ToLower(val), "happy", k)
+	}
+
+	// Finished and daily games are described too
+	game.Play("happy")
+	assert.Equal("Won", game.Attributes()["game.status"])
+
+	daily, err := CreateDaily(time.Now())
+	require.NoError(err)
+	assert.Equal("daily", daily.Attributes()["game.mode"])
+
+	adversarial, err := CreateAdversarial(GameOptions{HardMode: true})
+	require.NoError(err)
+	attrs = adversarial.Attributes()
+	assert.Equal("adversarial", attrs["game.mode"])
+	assert.Equal("true", attrs["game.hard_mode"])
+}
+
+func TestNoAnagramRepeats(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		opts GameOptions
+		err  error
+	}{
+		{opts: GameOptions{}, err: nil},
+		{opts: GameOptions{NoAnagramRepeats: true}, err: ErrAnagramRepeat},
+	}
+
+	for _, test := range tests {
+		game, err := CreateWithOptions("happy", test.opts)
+		require.NoError(err, "CreateWithOptions() returned error when creating Game")
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+
+		_, err = game.Play("least")
+		require.NoError(err)
+
+		for _, tw := range []string{"steal", "SLATE", "least"} {
+			attempts := len(v.Attempts)
+			_, err = game.Play(tw)
+			if test.err != nil {
+				assert.ErrorIs(err, test.err, tw)
+				assert.Len(v.Attempts, attempts, "rejected anagram shouldn't consume a turn")
+				continue
+			}
+			assert.NoError(err, tw)
+		}
+
+		// Guesses with different letters are still accepted
+		_, err = game.Play("bless")
+		assert.NoError(err)
+	}
+}
+
+func TestNote(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	const note = "zzq are unlikely, try vowels"
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	assert.Empty(game.Note())
+
+	require.NoError(game.SetNote(note))
+	assert.ErrorIs(game.SetNote(strings.Repeat("x", config.CONFIG_GAME_MAXNOTELENGTH+1)), ErrNoteLength)
+	assert.Equal(note, game.Note())
+
+	// The note persists in the store
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+	r, err := Retrieve(v.Id)
+	require.NoError(err)
+	assert.Equal(note, r.Note())
+
+	// The note is included in Describe
+	s, err := r.Describe()
+	require.NoError(err)
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.Equal(note, out["note"])
+
+	// The note is excluded from public output
+	game.Play("happy")
+	share, err := game.ShareGrid()
+	require.NoError(err)
+	assert.NotContains(share, note)
+	board, err := game.RenderAccessible()
+	require.NoError(err)
+	assert.NotContains(board, note)
+}
+
+func TestShowLetterCountHint(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		opts       GameOptions
+		distinct   int
+		repeated   bool
+	}{
+		{createWord: "happy", opts: GameOptions{ShowLetterCountHint: true}, distinct: 4, repeated: true},
+		{createWord: "proxy", opts: GameOptions{ShowLetterCountHint: true}, distinct: 5, repeated: false},
+		{createWord: "bless", opts: GameOptions{ShowLetterCountHint: true}, distinct: 4, repeated: true},
+		{createWord: "happy", opts: GameOptions{}},
+	}
+
+	for _, test := range tests {
+		game, err := CreateWithOptions(test.createWord, test.opts)
+		require.NoError(err, "CreateWithOptions() returned error when creating Game")
+
+		s, err := game.Describe()
+		require.NoError(err)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+
+		if !test.opts.ShowLetterCountHint {
+			assert.NotContains(out, "distinctLetters")
+			assert.NotContains(out, "repeatedLetters")
+			continue
+		}
+		assert.EqualValues(test.distinct, out["distinctLetters"], test.createWord)
+		assert.Equal(test.repeated, out["repeatedLetters"], test.createWord)
+		assert.NotContains(out, "secretWord")
+	}
+}
+
+func TestShowNearWin(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		opts    GameOptions
+		guess   string
+		nearWin bool
+	}{
+		{opts: GameOptions{ShowNearWin: true}, guess: "handy", nearWin: false}, // three greens
+		{opts: GameOptions{ShowNearWin: true}, guess: "hippy", nearWin: true},  // four greens
+		{opts: GameOptions{ShowNearWin: true}, guess: "happy", nearWin: false},
+		{opts: GameOptions{ShowNearWin: true}, guess: "zzzzz", nearWin: false},
+		{opts: GameOptions{}, guess: "hippy"},
+	}
+
+	for _, test := range tests {
+		game, err := CreateWithOptions("happy", test.opts)
+		require.NoError(err, "CreateWithOptions() returned error when creating Game")
+
+		s, _ := game.Play(test.guess)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(s), &out))
+
+		if !test.opts.ShowNearWin {
+			assert.NotContains(out, "nearWin")
+			continue
+		}
+		assert.Equal(test.nearWin, out["nearWin"], test.guess)
+	}
+}
+
+func TestElapsed(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer ResetClock()
+
+	game, err := Create("happy")
+	require.NoError(err)
+	v := game.(*wordleGame)
+	assert.Equal(now, v.CreatedAt)
+	assert.True(v.FinishedAt.IsZero())
+	assert.Equal(time.Duration(0), game.Elapsed())
+
+	// Elapsed time runs while the game is in play
+	now = now.Add(90 * time.Second)
+	assert.Equal(90*time.Second, game.Elapsed())
+	_, err = game.Play("heave")
+	require.NoError(err)
+	now = now.Add(30 * time.Second)
+	_, err = game.Play("happy")
+	require.NoError(err)
+	assert.Equal(now, v.FinishedAt)
+
+	// and stops when it finishes
+	now = now.Add(time.Hour)
+	assert.Equal(2*time.Minute, game.Elapsed())
+
+	out := map[string]interface{}{}
+	s, err := game.Describe()
+	require.NoError(err)
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.Equal("2022-03-01T12:00:00Z", out["createdAt"])
+	assert.Equal("2022-03-01T12:02:00Z", out["finishedAt"])
+	assert.EqualValues(2*time.Minute, out["elapsed"])
+
+	// Resigning finishes the game too
+	game, err = Create("happy")
+	require.NoError(err)
+	now = now.Add(time.Minute)
+	_, err = game.Resign()
+	require.NoError(err)
+	assert.Equal(time.Minute, game.Elapsed())
+
+	// In-play games have no finish time
+	game, err = Create("happy")
+	require.NoError(err)
+	s, err = game.Describe()
+	require.NoError(err)
+	out = map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.NotContains(out, "finishedAt")
+	assert.Contains(out, "createdAt")
+
+	// Games from before creation times were recorded
+	assert.Equal(time.Duration(0), wordleGame{GameStatus: InPlay}.Elapsed())
+}
+
+func TestSetClock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer ResetClock()
+
+	game, err := CreateWithOptions("happy", GameOptions{ResignGracePeriod: time.Minute})
+	require.NoError(err)
+	v := game.(*wordleGame)
+	assert.Equal(now, v.LastUpdated)
+
+	// Attempts are stamped with the injected time
+	for i := 1; i <= 3; i++ {
+		now = now.Add(time.Duration(i) * time.Second)
+		_, err = game.Play("heave")
+		require.NoError(err)
+		assert.Equal(now, v.LastUpdated)
+	}
+	timings, err := game.AttemptTimings()
+	require.NoError(err)
+	start := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal([]time.Time{start.Add(time.Second), start.Add(3 * time.Second), start.Add(6 * time.Second)}, timings)
+	assert.Equal(6*time.Second, game.Elapsed())
+
+	// A resignation becomes final once the clock passes its grace period
+	_, err = game.Resign()
+	require.NoError(err)
+	now = now.Add(59 * time.Second)
+	s, err := game.Describe()
+	require.NoError(err)
+	assert.Contains(s, `"gameStatus":"InPlay"`)
+	now = now.Add(time.Second)
+	s, err = game.Describe()
+	require.NoError(err)
+	assert.Contains(s, `"gameStatus":"Resigned"`)
+
+	// Resetting restores the system clock
+	ResetClock()
+	game, err = Create("happy")
+	require.NoError(err)
+	assert.WithinDuration(time.Now(), game.(*wordleGame).CreatedAt, time.Second)
+}
+
+func TestStatusPredicates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		plays    []string
+		resign   bool
+		status   GameStatusType
+		won      bool
+		lost     bool
+		finished bool
+	}{
+		{plays: []string{"heave"}, status: InPlay},
+		{plays: []string{"heave", "happy"}, status: Won, won: true, finished: true},
+		{plays: []string{"heave", "heave", "heave", "heave", "heave", "heave"}, status: Lost, lost: true, finished: true},
+		{plays: []string{"heave"}, resign: true, status: Resigned, finished: true},
+	}
+
+	for _, test := range tests {
+		game, err := Create("happy")
+		require.NoError(err)
+		for _, w := range test.plays {
+			_, err := game.Play(w)
+			require.NoError(err, w)
+		}
+		if test.resign {
+			_, err := game.Resign()
+			require.NoError(err)
+		}
+
+		assert.Equal(test.status, game.Status())
+		assert.Equal(test.won, game.IsWon(), test.status.String())
+		assert.Equal(test.lost, game.IsLost(), test.status.String())
+		assert.Equal(test.finished, game.IsFinished(), test.status.String())
+	}
+
+	// A resignation counts once its grace period ends
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer ResetClock()
+	game, err := CreateWithOptions("happy", GameOptions{ResignGracePeriod: time.Minute})
+	require.NoError(err)
+	_, err = game.Resign()
+	require.NoError(err)
+	assert.False(game.IsFinished())
+	now = now.Add(time.Minute)
+	assert.Equal(Resigned, game.Status())
+	assert.True(game.IsFinished())
+	assert.False(game.IsLost())
+}
+
+func TestPlayMixedCase(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		tryWord string
+		result  []LetterHint
+		valid   bool
+	}{
+		{tryWord: "HeAvE", result: []LetterHint{Green, Grey, Yellow, Grey, Grey}, valid: true},
+		{tryWord: "pAINT", result: []LetterHint{Yellow, Green, Grey, Grey, Grey}, valid: true},
+		{tryWord: "ZzZzZ", result: []LetterHint{Blank, Blank, Blank, Blank, Blank}},
+		{tryWord: "hapPY", result: []LetterHint{Green, Green, Green, Green, Green}, valid: true},
+	}
+
+	game, err := Create("happy")
+	require.NoError(err)
+	for _, test := range tests {
+		game.Play(test.tryWord)
+	}
+
+	// Guesses are scored regardless of case, and echoed as typed
+	out := map[string]interface{}{}
+	s, err := game.Describe()
+	require.NoError(err)
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	attempts := out["attempts"].([]interface{})
+	require.Len(attempts, len(tests))
+	views, err := game.GetAttempts()
+	require.NoError(err)
+	for i, test := range tests {
+		assert.Equal(test.tryWord, attempts[i].(map[string]interface{})["tryWord"])
+		assert.Equal(test.tryWord, views[i].TryWord)
+		assert.Equal(test.result, views[i].Hints, test.tryWord)
+		assert.Equal(test.valid, views[i].IsValidWord, test.tryWord)
+	}
+	assert.Equal(Won, game.Status())
+	assert.Equal("HAPPY", out["secretWord"])
+
+	// State derived from the guesses uses the scored form
+	ks, err := game.KeyboardState()
+	require.NoError(err)
+	assert.Equal(Green, ks["H"])
+	assert.Equal(Green, ks["A"])
+	assert.Equal(Grey, ks["E"])
+	assert.NotContains(ks, "h")
+}
+
+func TestDictionaryReload(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	_, err = game.Play("puppy")
+	require.NoError(err)
+
+	// A secret word no longer in the dictionary can still be won
+	list := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(os.WriteFile(list, []byte("heave\nseven\n"), 0o644))
+	require.NoError(dictionary.Reload(list))
+	defer dictionary.Reload("")
+
+	_, err = game.Play("puppy")
+	assert.ErrorIs(err, ErrInvalidWord)
+	_, err = game.Play("heave")
+	require.NoError(err)
+	_, err = game.Play("happy")
+	require.NoError(err)
+	assert.True(game.IsWon())
+}
@@ -0,0 +1,73 @@
+package game
+
+import "fmt"
+
+// Describes how a guess broke the hard mode rules
+type HardModeError struct {
+	Letter   rune
+	Position int  // 1-based position of a green letter, or of a yellow letter's wrong spot
+	Green    bool // the letter had to stay at Position
+}
+
+func (e *HardModeError) Error() string {
+	if e.Green {
+		return fmt.Sprintf("%s: %c must be in position %d", ErrHardMode, e.Letter, e.Position)
+	}
+	if e.Position > 0 {
+		return fmt.Sprintf("%s: %c can't be in position %d", ErrHardMode, e.Letter, e.Position)
+	}
+	return fmt.Sprintf("%s: guess must contain %c", ErrHardMode, e.Letter)
+}
+
+func (e *HardModeError) Unwrap() error {
+	return ErrHardMode
+}
+
+// Checks that word reuses the hints of every earlier valid attempt: greens must
+// stay in place and yellows must be used, but not where they were yellow.
+func (g wordleGame) checkHardMode(word string) error {
+	w := []rune(word)
+	for _, a := range g.Attempts {
+		if !a.IsValidWord {
+			continue
+		}
+		try := []rune(a.word())
+		for i, h := range a.TryResult {
+			if i >= len(try) || i >= len(w) {
+				break
+			}
+			c := try[i]
+			switch h {
+			case Green:
+				if w[i] != c {
+					return &HardModeError{Letter: c, Position: i + 1, Green: true}
+				}
+			case Yellow:
+				if w[i] == c {
+					return &HardModeError{Letter: c, Position: i + 1}
+				}
+				if !containsOutside(w, c, a.TryResult, try) {
+					return &HardModeError{Letter: c}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reports whether word has the letter c in a position that isn't already
+// taken by a green letter of the same attempt
+func containsOutside(word []rune, c rune, hints []LetterHint, try []rune) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] != c {
+			continue
+		}
+		if i < len(hints) && i < len(try) && hints[i] == Green && try[i] == c {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
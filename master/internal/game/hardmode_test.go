@@ -0,0 +1,73 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardMode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := CreateWithOptions("happy", GameOptions{HardMode: true})
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+	_, err = game.Play("heave") // H green, A yellow in position 3
+	require.NoError(err)
+
+	tests := []struct {
+		guess   string
+		err     error
+		message string
+	}{
+		{guess: "paint", err: ErrHardMode, message: "H must be in position 1"},
+		{guess: "hoist", err: ErrHardMode, message: "guess must contain A"},
+		{guess: "heavy", err: ErrHardMode, message: "A can't be in position 3"},
+		{guess: "zzzzz", err: ErrInvalidWord},
+		{guess: "handy"},
+	}
+
+	v := game.(*wordleGame)
+	for _, test := range tests {
+		before := len(v.Attempts)
+		_, err := game.Play(test.guess)
+		if test.err == ErrHardMode {
+			// Rejected without using up an attempt
+			assert.ErrorIs(err, ErrHardMode, test.guess)
+			var herr *HardModeError
+			if assert.True(errors.As(err, &herr), test.guess) {
+				assert.Contains(herr.Error(), test.message)
+			}
+			assert.Len(v.Attempts, before, test.guess)
+			continue
+		}
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, test.guess)
+			continue
+		}
+		assert.NoError(err, test.guess)
+	}
+
+	// Constraints accumulate across attempts: after handy, Y must stay green
+	_, err = game.Play("harsh")
+	assert.ErrorIs(err, ErrHardMode)
+	assert.Contains(err.Error(), "Y must be in position 5")
+	s, err := game.Play("happy")
+	require.NoError(err)
+
+	// The mode is shown in the report
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.Equal("Won", out["gameStatus"])
+	assert.Equal(map[string]interface{}{"hardMode": true}, out["options"])
+
+	// Without hard mode any valid word is accepted
+	game, err = Create("happy")
+	require.NoError(err)
+	game.Play("heave")
+	_, err = game.Play("paint")
+	assert.NoError(err)
+}
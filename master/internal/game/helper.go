@@ -1,19 +1,27 @@
 package game
 
 import (
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"aluance.io/wordleserver/internal/config"
 	"aluance.io/wordleserver/internal/dictionary"
 )
 
 func validateWord(s string, options ...interface{}) (string, error) {
+	return validateWordLength(s, config.CONFIG_GAME_WORDLENGTH, options...)
+}
+
+// Validates a word of the given length, for games not using the configured one
+func validateWordLength(s string, length int, options ...interface{}) (string, error) {
 	optSecretWord := ""
 	if len(options) > 0 {
 		optSecretWord = strings.ToUpper(options[0].(string))
 	}
 
-	if len(s) != config.CONFIG_GAME_WORDLENGTH {
+	if utf8.RuneCountInString(s) != length {
 		return s, ErrWordLength
 	}
 
@@ -24,9 +32,35 @@ func validateWord(s string, options ...interface{}) (string, error) {
 		return strings.ToUpper(s), nil // automatically valid
 	}
 
+	// Relaxed mode skips the dictionary but still requires letters only.
+	if config.CONFIG_RELAXED_VALIDATION {
+		if !isAlpha(s) {
+			return s, ErrInvalidWord
+		}
+		return s, nil
+	}
+
 	if !dictionary.IsWordValid(s) {
 		return s, ErrInvalidWord
 	}
 
 	return s, nil
 }
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the letters of a word in sorted order, so anagrams share a key
+func anagramKey(s string) string {
+	r := []rune(strings.ToUpper(s))
+	sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+
+	return string(r)
+}
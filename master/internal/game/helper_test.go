@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"aluance.io/wordleserver/internal/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,3 +45,54 @@ func TestValidateWords(t *testing.T) {
 		assert.Equal(test.result, res, "return is not as expected")
 	}
 }
+
+func TestValidateWordsRelaxed(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(v bool) { config.CONFIG_RELAXED_VALIDATION = v }(config.CONFIG_RELAXED_VALIDATION)
+
+	tests := []struct {
+		s       string
+		relaxed bool
+		result  string
+		err     error
+	}{
+		{s: "xxxxx", relaxed: false, result: "XXXXX", err: ErrInvalidWord},
+		{s: "xxxxx", relaxed: true, result: "XXXXX", err: nil},
+		{s: "xx1xx", relaxed: true, result: "XX1XX", err: ErrInvalidWord},
+		{s: "xxx", relaxed: true, result: "xxx", err: ErrWordLength},
+		{s: "blank", relaxed: true, result: "BLANK", err: nil},
+	}
+
+	for _, test := range tests {
+		config.CONFIG_RELAXED_VALIDATION = test.relaxed
+		res, err := validateWord(test.s)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, test.s)
+			assert.Equal(test.result, res)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err, test.s)
+		assert.Equal(test.result, res, "return is not as expected")
+	}
+
+	// A relaxed guess is accepted by Play, and rejected once strict again
+	config.CONFIG_RELAXED_VALIDATION = true
+	g, err := Create("happy")
+	assert.NoError(err)
+	_, err = g.Play("xxxxx")
+	assert.NoError(err)
+
+	config.CONFIG_RELAXED_VALIDATION = false
+	_, err = g.Play("xxxxx")
+	assert.ErrorIs(err, ErrInvalidWord)
+}
+
+func TestAnagramKey(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(anagramKey("least"), anagramKey("STEAL"))
+	assert.Equal(anagramKey("slate"), anagramKey("tales"))
+	assert.NotEqual(anagramKey("happy"), anagramKey("puppy"))
+	assert.Equal("AHPPY", anagramKey("happy"))
+}
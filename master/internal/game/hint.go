@@ -0,0 +1,91 @@
+package game
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
+
+// A secret letter revealed by Game.Hint. Positions count from 0.
+type LetterReveal struct {
+	Position int    `json:"position"`
+	Letter   string `json:"letter"`
+}
+
+// Reveals one secret letter the player hasn't found, the leftmost position
+// that is neither green in an attempt nor revealed by an earlier hint. Returns
+// the reveal as JSON. With the HintCostsAttempt option each hint uses up a
+// guess, and a hint is refused if it would leave no guesses. Adversarial games
+// refuse hints until the hints so far leave a single candidate.
+func (g *wordleGame) Hint() (string, error) {
+	return g.update((*wordleGame).hint, "")
+}
+
+func (g *wordleGame) hint(string) (string, error) {
+	g.settleResign()
+	if g.GameStatus != InPlay {
+		return "{}", ErrGameOver
+	}
+	if g.Options.HintCostsAttempt && g.RemainingAttempts() < 2 {
+		return "{}", ErrOutOfTurns
+	}
+
+	if g.Options.Adversarial {
+		// The secret is only chosen once a single candidate is left
+		cands, err := g.candidates()
+		if err != nil {
+			return "{}", err
+		}
+		if len(cands) != 1 {
+			return "{}", ErrAdversarialHint
+		}
+		g.SecretWord = cands[0]
+	}
+
+	pos := g.nextHintPosition()
+	if pos < 0 {
+		return "{}", ErrNoHint
+	}
+	g.HintedPositions = append(g.HintedPositions, pos)
+	g.HintsUsed++
+	g.LastUpdated = now()
+
+	b, err := json.Marshal(LetterReveal{Position: pos, Letter: string([]rune(g.SecretWord)[pos])})
+	if err != nil {
+		return "{}", err
+	}
+
+	return string(b), nil
+}
+
+// Returns the leftmost position not yet known to the player, or -1 if every
+// letter is known
+func (g wordleGame) nextHintPosition() int {
+	known := make([]bool, utf8.RuneCountInString(g.SecretWord))
+	for _, a := range g.Attempts {
+		for i, h := range a.TryResult {
+			if h == Green && i < len(known) {
+				known[i] = true
+			}
+		}
+	}
+	for _, i := range g.HintedPositions {
+		known[i] = true
+	}
+
+	for i, k := range known {
+		if !k {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Returns the guesses used up by hints
+func (g wordleGame) hintCost() int {
+	if g.Options.HintCostsAttempt {
+		return g.HintsUsed
+	}
+
+	return 0
+}
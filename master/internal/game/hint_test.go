@@ -0,0 +1,80 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"aluance.io/wordleserver/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHint(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	hint := func(g Game) (LetterReveal, error) {
+		r := LetterReveal{}
+		s, err := g.Hint()
+		if err == nil {
+			require.NoError(json.Unmarshal([]byte(s), &r))
+		}
+		return r, err
+	}
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	v := game.(*wordleGame)
+
+	// Hints never reveal a position already green, nor one already hinted
+	game.Play("heave") // H is green
+	tests := []LetterReveal{
+		{Position: 1, Letter: "A"},
+		{Position: 2, Letter: "P"},
+	}
+	for _, test := range tests {
+		r, err := hint(game)
+		require.NoError(err)
+		assert.Equal(test, r)
+	}
+	game.Play("hippy") // H, P, P and Y are green, and A was hinted
+	_, err = hint(game)
+	assert.ErrorIs(err, ErrNoHint)
+	assert.Equal(2, v.HintsUsed)
+
+	// Hints are reported by Describe, and don't use up guesses by default
+	s, err := game.Describe()
+	require.NoError(err)
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.EqualValues(2, out["hintsUsed"])
+	assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS-2, game.RemainingAttempts())
+
+	game.Play("happy")
+	_, err = hint(game)
+	assert.ErrorIs(err, ErrGameOver)
+
+	// Without attempts, hints reveal the letters left to right
+	game, err = Create("happy")
+	require.NoError(err)
+	for i, l := range "HAPPY" {
+		r, err := hint(game)
+		require.NoError(err, i)
+		assert.Equal(LetterReveal{Position: i, Letter: string(l)}, r)
+	}
+	_, err = hint(game)
+	assert.ErrorIs(err, ErrNoHint)
+
+	// Hints may cost a guess, but always leave one
+	game, err = CreateWithOptions("happy", GameOptions{MaxAttempts: 3, HintCostsAttempt: true})
+	require.NoError(err)
+	_, err = hint(game)
+	require.NoError(err)
+	assert.Equal(2, game.RemainingAttempts())
+	game.Play("heave")
+	assert.Equal(1, game.RemainingAttempts())
+	_, err = hint(game)
+	assert.ErrorIs(err, ErrOutOfTurns)
+	game.Play("handy")
+	assert.Equal(Lost, game.(*wordleGame).GameStatus)
+}
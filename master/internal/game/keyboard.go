@@ -0,0 +1,60 @@
+package game
+
+const keyboardLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Returns the strongest hint seen for each letter A-Z across all valid
+// attempts. Green beats Yellow beats Grey; letters not yet tried are Blank.
+func (g wordleGame) KeyboardState() (map[string]LetterHint, error) {
+	return keyboardState(g.Attempts), nil
+}
+
+// Recomputes the state derived from the attempts: the valid attempt count
+// and, once finished, the share text. Safe to call on a game loaded from any
+// store.
+func (g *wordleGame) RebuildDerived() {
+	g.ValidAttempts = 0
+	for _, a := range g.Attempts {
+		if a.IsValidWord {
+			g.ValidAttempts++
+		}
+	}
+	if g.GameStatus != InPlay {
+		g.ShareText = g.shareText()
+	}
+}
+
+func keyboardState(attempts []*WordleAttempt) map[string]LetterHint {
+	ks := make(map[string]LetterHint, len(keyboardLetters))
+	for _, r := range keyboardLetters {
+		ks[string(r)] = Blank
+	}
+
+	for _, a := range attempts {
+		if !a.IsValidWord {
+			continue
+		}
+		for i, r := range []rune(a.word()) {
+			if i >= len(a.TryResult) {
+				break
+			}
+			k := string(r)
+			if hintStrength(a.TryResult[i]) > hintStrength(ks[k]) {
+				ks[k] = a.TryResult[i]
+			}
+		}
+	}
+
+	return ks
+}
+
+func hintStrength(h LetterHint) int {
+	switch h {
+	case Green:
+		return 3
+	case Yellow:
+		return 2
+	case Grey:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyboardState(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+
+	ks, err := game.KeyboardState()
+	require.NoError(err)
+	assert.Len(ks, 26)
+	for k, h := range ks {
+		assert.Equal(Blank, h, k)
+	}
+
+	game.Play("heave") // H green, A yellow, E/V grey
+	game.Play("zzzzz") // invalid, ignored
+	game.Play("paint") // P yellow, A green
+
+	tests := map[string]LetterHint{
+		"H": Green,
+		"A": Green, // upgraded from yellow
+		"P": Yellow,
+		"E": Grey,
+		"V": Grey,
+		"I": Grey,
+		"N": Grey,
+		"T": Grey,
+		"Z": Blank,
+		"Y": Blank,
+	}
+
+	ks, err = game.KeyboardState()
+	require.NoError(err)
+	for k, h := range tests {
+		assert.Equal(h, ks[k], k)
+	}
+
+	// The returned map is a copy
+	ks["H"] = Red
+	again, err := game.KeyboardState()
+	require.NoError(err)
+	assert.Equal(Green, again["H"])
+
+	// Reading the state doesn't change the game, so it is safe alongside
+	// other readers of the same stored game
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ks, err := game.KeyboardState()
+			assert.NoError(err)
+			assert.Equal(Green, ks["H"])
+			game.Describe()
+		}()
+	}
+	wg.Wait()
+
+	// A game reloaded from its stored form derives the same state
+	s, err := game.DebugReport()
+	require.NoError(err)
+	reloaded := &wordleGame{}
+	require.NoError(json.Unmarshal([]byte(s), reloaded))
+	rks, err := reloaded.KeyboardState()
+	require.NoError(err)
+	assert.Equal(again, rks)
+
+	original := game.(*wordleGame)
+	reloaded.RebuildDerived()
+	assert.Equal(original.ValidAttempts, reloaded.ValidAttempts)
+	assert.Equal(original.ShareText, reloaded.ShareText)
+}
+
+func TestKeyboardStateUpgrade(t *testing.T) {
+	assert := assert.New(t)
+
+	attempt := func(word string, hints ...LetterHint) *WordleAttempt {
+		return &WordleAttempt{TryWord: word, IsValidWord: true, TryResult: hints}
+	}
+
+	tests := []struct {
+		attempts []*WordleAttempt
+		letter   string
+		result   LetterHint
+	}{
+		{attempts: []*WordleAttempt{attempt("PUPPY", Grey, Grey, Green, Green, Green)}, letter: "P", result: Green},
+		{attempts: []*WordleAttempt{
+			attempt("SEVEN", Grey, Grey, Grey, Grey, Grey),
+			attempt("TENSE", Grey, Yellow, Grey, Grey, Grey),
+		}, letter: "E", result: Yellow},
+		{attempts: []*WordleAttempt{
+			attempt("TENSE", Grey, Yellow, Grey, Grey, Grey),
+			attempt("SEVEN", Grey, Grey, Grey, Grey, Grey),
+		}, letter: "E", result: Yellow},
+		{attempts: []*WordleAttempt{
+			attempt("TENSE", Grey, Yellow, Grey, Grey, Grey),
+			attempt("HELLO", Grey, Green, Grey, Grey, Grey),
+		}, letter: "E", result: Green},
+		{attempts: []*WordleAttempt{
+			{TryWord: "ZZZZZ", IsValidWord: false, TryResult: []LetterHint{Blank, Blank, Blank, Blank, Blank}},
+		}, letter: "Z", result: Blank},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.result, keyboardState(test.attempts)[test.letter], test.letter)
+	}
+}
+
+func TestKeyboardStateReport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+
+	s, err := game.Play("heave")
+	require.NoError(err)
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	ks, ok := out["keyboardState"].(map[string]interface{})
+	require.True(ok)
+	assert.Len(ks, 26)
+	assert.Equal("Green", ks["H"])
+	assert.Equal("Yellow", ks["A"])
+	assert.Equal("Grey", ks["E"])
+	assert.Equal("Blank", ks["Z"])
+}
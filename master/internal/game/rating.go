@@ -0,0 +1,38 @@
+package game
+
+import "math"
+
+const (
+	ratingK     = 32.0   // largest possible rating change
+	ratingBase  = 1500.0 // rating of a word of average difficulty
+	ratingScale = 400.0  // rating difference giving 10:1 expected odds
+	ratingPar   = 3      // par of a word of average difficulty
+)
+
+// Returns the ELO-style rating change earned for a finished game by a player
+// rated currentRating. The word is rated by its solver par and rarity;
+// solving it in fewer guesses than par scores best, and any loss scores zero.
+// Solving hard words quickly gains the most, failing easy ones loses the most.
+func RatingDelta(g Game, currentRating float64) (float64, error) {
+	wg, ok := g.(*wordleGame)
+	if !ok || wg == nil {
+		return 0, ErrNilResult
+	}
+	if !wg.IsFinished() {
+		return 0, ErrGameInPlay
+	}
+
+	r, err := DailyFairness(wg.SecretWord)
+	if err != nil {
+		return 0, err
+	}
+	wordRating := ratingBase + float64(r.Par-ratingPar)*ratingScale/2 + r.Rarity*ratingScale/2
+	expected := 1 / (1 + math.Pow(10, (wordRating-currentRating)/ratingScale))
+
+	score := 0.0
+	if wg.IsWon() {
+		score = math.Max(0, math.Min(1, 0.5+float64(r.Par-wg.ValidAttempts)/4))
+	}
+
+	return ratingK * (score - expected), nil
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"testing"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/dictionary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingDelta(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Plays the guesses, then resigns if the game is still in play
+	play := func(secret string, guesses ...string) Game {
+		game, err := Create(secret)
+		require.NoError(err, "Create() returned error when creating Game")
+		for _, tw := range guesses {
+			game.Play(tw)
+		}
+		if game.(*wordleGame).GameStatus == InPlay {
+			game.Resign()
+		}
+		return game
+	}
+
+	hard, easy := "puppy", config.CONFIG_SOLVER_OPENER
+	par, err := dictionary.Par(hard, config.CONFIG_SOLVER_OPENER)
+	require.NoError(err)
+	require.Greater(par, 3, "test word should be hard")
+
+	tests := []struct {
+		name     string
+		game     Game
+		positive bool
+	}{
+		{name: "fast solve of a hard word", game: play(hard, "bless", hard), positive: true},
+		{name: "loss on an easy word", game: play(easy, "bless", "happy", "puppy", "seven", "paint", "heave"), positive: false},
+		{name: "resigned hard word", game: play(hard), positive: false},
+	}
+
+	require.Equal(Lost, tests[1].game.(*wordleGame).GameStatus)
+
+	for _, test := range tests {
+		delta, err := RatingDelta(test.game, 1500)
+		require.NoError(err, test.name)
+		if test.positive {
+			assert.Greater(delta, 0.0, test.name)
+		} else {
+			assert.Less(delta, 0.0, test.name)
+		}
+		assert.LessOrEqual(delta, ratingK, test.name)
+		assert.GreaterOrEqual(delta, -ratingK, test.name)
+	}
+
+	// Strong players gain less from the same result
+	low, err := RatingDelta(tests[0].game, 1200)
+	require.NoError(err)
+	high, err := RatingDelta(tests[0].game, 2200)
+	require.NoError(err)
+	assert.Greater(low, high)
+
+	game, err := Create(hard)
+	require.NoError(err)
+	_, err = RatingDelta(game, 1500)
+	assert.ErrorIs(err, ErrGameInPlay)
+
+	// A lapsed resignation counts as finished
+	delta, err := RatingDelta(lapseResign(game), 1500)
+	require.NoError(err)
+	assert.Less(delta, 0.0)
+	_, err = RatingDelta(nil, 1500)
+	assert.ErrorIs(err, ErrNilResult)
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+)
+
+// The game state reported by Describe, Play and the other game actions. Keys
+// only reported in some states are omitted otherwise.
+type StatusReport struct {
+	Id                 string                `json:"id"`
+	GameStatus         GameStatusType        `json:"gameStatus"`
+	SecretWord         string                `json:"secretWord,omitempty"` // only once the game is finished
+	WordLength         int                   `json:"wordLength"`
+	MaxAttempts        int                   `json:"maxAttempts"`
+	Attempts           []*WordleAttempt      `json:"attempts"`
+	ValidAttempts      int                   `json:"validAttempts"`
+	AttemptsUsed       int                   `json:"attemptsUsed"`
+	AttemptsRemaining  int                   `json:"attemptsRemaining"`
+	KeyboardState      map[string]LetterHint `json:"keyboardState"`
+	LastUpdated        time.Time             `json:"lastUpdated"`
+	CreatedAt          time.Time             `json:"createdAt"`
+	FinishedAt         *time.Time            `json:"finishedAt,omitempty"`
+	Elapsed            time.Duration         `json:"elapsed"`
+	ShareText          string                `json:"shareText,omitempty"`
+	HintsUsed          int                   `json:"hintsUsed"`
+	HintedPositions    []int                 `json:"hintedPositions,omitempty"`
+	ShareKey           string                `json:"shareCode,omitempty"`
+	Daily              bool                  `json:"daily,omitempty"`
+	PuzzleNumber       *int                  `json:"puzzleNumber,omitempty"` // daily games only
+	StatusLog          []StatusTransition    `json:"transitions,omitempty"`
+	Options            GameOptions           `json:"options"`
+	PlayerNote         string                `json:"note,omitempty"`
+	ResignPendingUntil *time.Time            `json:"resignPendingUntil,omitempty"`
+
+	// Reported with the ShowNearWin, ShowLetterCountHint and Adversarial options
+	NearWin         *bool `json:"nearWin,omitempty"`
+	DistinctLetters *int  `json:"distinctLetters,omitempty"`
+	RepeatedLetters *bool `json:"repeatedLetters,omitempty"`
+	CandidateCount  *int  `json:"candidateCount,omitempty"`
+
+	// Reported by Play for a guess rejected with the SkipInvalidGuesses option
+	RejectedGuess *WordleAttempt `json:"rejectedGuess,omitempty"`
+
+	// Reported once the game is won
+	WinningAttempt int      `json:"winningAttempt,omitempty"`
+	WinType        *WinType `json:"winType,omitempty"`
+}
+
+// Returns the report of the game's current state
+func (g wordleGame) report() StatusReport {
+	r := StatusReport{
+		Id:                 g.Id,
+		GameStatus:         g.GameStatus,
+		WordLength:         g.WordLength,
+		MaxAttempts:        g.MaxAttempts,
+		Attempts:           g.Attempts,
+		ValidAttempts:      g.ValidAttempts,
+		AttemptsUsed:       len(g.Attempts),
+		AttemptsRemaining:  g.RemainingAttempts(),
+		KeyboardState:      keyboardState(g.Attempts),
+		LastUpdated:        g.LastUpdated,
+		CreatedAt:          g.CreatedAt,
+		Elapsed:            g.Elapsed(),
+		ShareText:          g.ShareText,
+		HintsUsed:          g.HintsUsed,
+		HintedPositions:    g.HintedPositions,
+		ShareKey:           g.ShareKey,
+		Daily:              g.Daily,
+		StatusLog:          g.StatusLog,
+		Options:            g.Options,
+		PlayerNote:         g.PlayerNote,
+		ResignPendingUntil: g.ResignPendingUntil,
+	}
+	if g.GameStatus != InPlay {
+		r.SecretWord = g.SecretWord
+	}
+	if !g.FinishedAt.IsZero() {
+		r.FinishedAt = &g.FinishedAt
+	}
+	if g.Daily {
+		r.PuzzleNumber = &g.PuzzleNumber
+	}
+	if g.Options.ShowNearWin {
+		nearWin := g.isNearWin()
+		r.NearWin = &nearWin
+	}
+	if g.Options.ShowLetterCountHint {
+		n := distinctLetters(g.SecretWord)
+		repeated := n < utf8.RuneCountInString(g.SecretWord)
+		r.DistinctLetters, r.RepeatedLetters = &n, &repeated
+	}
+	if g.Options.Adversarial {
+		if cands, err := g.candidates(); err == nil {
+			n := len(cands)
+			r.CandidateCount = &n
+		}
+	}
+	if g.GameStatus == Won {
+		winType := g.winType()
+		r.WinningAttempt = len(g.Attempts)
+		r.WinType = &winType
+	}
+
+	return r
+}
+
+func (g wordleGame) statusReport() string {
+	return marshalReport(g.report())
+}
+
+// Returns the status report with the invalid guess tryWord, which isn't kept
+// as an attempt
+func (g wordleGame) rejectedReport(tryWord string) string {
+	r := g.report()
+	r.RejectedGuess = &WordleAttempt{
+		TryWord:     tryWord,
+		IsValidWord: false,
+		TryResult:   make([]LetterHint, g.wordLength()),
+		TimeStamp:   serverTime(),
+	}
+
+	return marshalReport(r)
+}
+
+// Returns a report from Play, Resign or the other game actions without the
+// private note, like DescribePublic
+func PublicReport(report string) (string, error) {
+	var r StatusReport
+	if err := json.Unmarshal([]byte(report), &r); err != nil {
+		return "{}", ErrSerialization
+	}
+	r.PlayerNote = ""
+
+	return marshalReport(r), nil
+}
+
+func marshalReport(r StatusReport) string {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "{}"
+	}
+
+	return string(b)
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const TEST_REPORT_GOLDEN_FILEPATH = "testdata/status_report.golden.json"
+
+func TestStatusReportGolden(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer ResetClock()
+	SetIDGenerator(func() string { return "c8g0golden00000000000" })
+	defer SetIDGenerator(nil)
+
+	game, err := CreateWithOptions("happy", GameOptions{ShowNearWin: true, ShowLetterCountHint: true})
+	require.NoError(err)
+	for _, w := range []string{"heave", "zzzzz", "Happy"} {
+		now = now.Add(20 * time.Second)
+		game.Play(w)
+	}
+	s, err := game.Describe()
+	require.NoError(err)
+
+	var out bytes.Buffer
+	require.NoError(json.Indent(&out, []byte(s), "", "  "))
+	golden, err := os.ReadFile(TEST_REPORT_GOLDEN_FILEPATH)
+	require.NoError(err)
+	assert.Equal(string(bytes.TrimSpace(golden)), out.String())
+
+	// The report keys match the StatusReport struct
+	var r StatusReport
+	require.NoError(json.Unmarshal([]byte(s), &r))
+	b, err := json.Marshal(r)
+	require.NoError(err)
+	assert.JSONEq(s, string(b))
+}
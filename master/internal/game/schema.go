@@ -0,0 +1,136 @@
+package game
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"unicode/utf8"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/store"
+)
+
+// Version of the stored game schema. Version 0 is any game stored before the
+// version was recorded, without a word length or attempt limit.
+const gameSchemaVersion = 1
+
+// Encodes a game as JSON, including its secret word, so it can be stored by
+// backends that persist bytes and restored with UnmarshalGame
+func MarshalGame(game Game) ([]byte, error) {
+	g, ok := game.(*wordleGame)
+	if !ok || g == nil {
+		return nil, ErrSerialization
+	}
+
+	return json.Marshal(g)
+}
+
+// Decodes a game encoded by MarshalGame, upgrading older schemas
+func UnmarshalGame(b []byte) (Game, error) {
+	g := &wordleGame{}
+	if err := json.Unmarshal(b, g); err != nil {
+		return nil, ErrSerialization
+	}
+
+	return g, nil
+}
+
+// Returns the game in content loaded from s. Persistent stores return the
+// game encoded, with their own codec if they are a store.Decoder and as JSON
+// otherwise.
+func decodeGame(s store.Store, content interface{}) (*wordleGame, error) {
+	var b []byte
+	switch c := content.(type) {
+	case *wordleGame:
+		return c, nil
+	case []byte:
+		b = c
+	case string:
+		b = []byte(c)
+	default:
+		return nil, ErrSerialization
+	}
+
+	if d, ok := s.(store.Decoder); ok {
+		g := &wordleGame{}
+		if err := d.Decode(b, g); err != nil {
+			return nil, ErrSerialization
+		}
+		return g, nil
+	}
+	g, err := UnmarshalGame(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.(*wordleGame), nil
+}
+
+// The stored fields of a game, without its methods so encoding them doesn't
+// recurse
+type storedGame wordleGame
+
+// Encodes the game with encoding/gob, which store.GobCodec uses for games
+func (g *wordleGame) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode((*storedGame)(g)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decodes a game encoded by MarshalBinary, upgrading games stored with an
+// older schema
+func (g *wordleGame) UnmarshalBinary(data []byte) error {
+	var s storedGame
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+
+	return g.restore(s)
+}
+
+func (g *wordleGame) UnmarshalJSON(b []byte) error {
+	var s storedGame
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return g.restore(s)
+}
+
+// Sets the game to the stored fields s, upgrading an older schema
+func (g *wordleGame) restore(s storedGame) error {
+	*g = wordleGame(s)
+	if g.SchemaVersion < gameSchemaVersion {
+		return g.upgrade()
+	}
+
+	return nil
+}
+
+// Applies the defaults of the current schema to a game stored by an older one
+func (g *wordleGame) upgrade() error {
+	if g.WordLength < 1 {
+		g.WordLength = config.CONFIG_GAME_WORDLENGTH
+		if len(g.SecretWord) > 0 {
+			g.WordLength = utf8.RuneCountInString(g.SecretWord)
+		}
+	}
+	if g.MaxAttempts < 1 {
+		g.MaxAttempts = config.CONFIG_GAME_MAXVALIDATTEMPTS
+	}
+	if g.Attempts == nil {
+		g.Attempts = []*WordleAttempt{}
+	}
+	for _, a := range g.Attempts {
+		if a.TryResult == nil {
+			a.TryResult = make([]LetterHint, g.WordLength)
+		}
+	}
+	g.SchemaVersion = gameSchemaVersion
+	g.RebuildDerived()
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A game as stored before the schema was versioned
+const legacyGameJSON = `{
+	"id": "legacy0001",
+	"gameStatus": "InPlay",
+	"secretWord": "HAPPY",
+	"attempts": [
+		{"tryWord": "BLESS", "isValidWord": true, "tryResult": ["Grey", "Grey", "Grey", "Grey", "Grey"]},
+		{"tryWord": "ZZZZZ", "isValidWord": false}
+	],
+	"lastUpdated": "2022-01-02T09:00:00Z"
+}`
+
+func TestLegacySchema(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := &wordleGame{}
+	require.NoError(json.Unmarshal([]byte(legacyGameJSON), g))
+
+	// Defaults are applied to the old game
+	assert.Equal(gameSchemaVersion, g.SchemaVersion)
+	assert.Equal(config.CONFIG_GAME_WORDLENGTH, g.WordLength)
+	assert.Equal(config.CONFIG_GAME_MAXVALIDATTEMPTS, g.MaxAttempts)
+	assert.Equal(1, g.ValidAttempts)
+	require.Len(g.Attempts, 2)
+	assert.Len(g.Attempts[1].TryResult, config.CONFIG_GAME_WORDLENGTH)
+
+	// Current games decode unchanged
+	game, err := CreateWithOptions("happy", GameOptions{MaxAttempts: 3})
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+	b, err := game.(*wordleGame).MarshalBinary()
+	require.NoError(err)
+	current := &wordleGame{}
+	require.NoError(current.UnmarshalBinary(b))
+	assert.Equal(3, current.MaxAttempts)
+	assert.Equal(gameSchemaVersion, current.SchemaVersion)
+
+	// A legacy game stored as bytes is retrieved and playable
+	gs, err := store.WordleStore()
+	require.NoError(err)
+	require.NoError(gs.Save("legacy0001", []byte(legacyGameJSON)))
+	defer gs.Delete("legacy0001")
+
+	r, err := Retrieve("legacy0001")
+	require.NoError(err)
+	s, err := r.Play("happy")
+	require.NoError(err)
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.Equal("Won", out["gameStatus"])
+	assert.NotContains(out, "schemaVersion")
+
+	require.NoError(gs.Save("legacy0002", []byte("{not json")))
+	defer gs.Delete("legacy0002")
+	_, err = Retrieve("legacy0002")
+	assert.ErrorIs(err, ErrSerialization)
+}
+
+func TestMarshalGame(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	game.Play("heave")
+	game.Play("zzzzz")
+
+	b, err := MarshalGame(game)
+	require.NoError(err)
+	restored, err := UnmarshalGame(b)
+	require.NoError(err)
+
+	// The restored game has the same state, and plays on independently
+	original := game.(*wordleGame)
+	v, ok := restored.(*wordleGame)
+	require.True(ok)
+	assert.Equal(original.Id, v.Id)
+	assert.Equal(original.GameStatus, v.GameStatus)
+	assert.Equal(original.SecretWord, v.SecretWord)
+	assert.Equal(original.ValidAttempts, v.ValidAttempts)
+	require.Len(v.Attempts, len(original.Attempts))
+	for i, a := range original.Attempts {
+		assert.Equal(a.TryResult, v.Attempts[i].TryResult)
+	}
+
+	_, err = v.play("handy")
+	require.NoError(err)
+	s, err := v.play("happy")
+	require.NoError(err)
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.Equal("Won", out["gameStatus"])
+	assert.Equal(InPlay, original.GameStatus)
+
+	// Stored as bytes or a string, the game is retrieved the same way
+	gs, err := store.WordleStore()
+	require.NoError(err)
+	for _, content := range []interface{}{b, string(b)} {
+		require.NoError(gs.Save(original.Id, content))
+		r, err := Retrieve(original.Id)
+		require.NoError(err)
+		d, err := r.Describe()
+		require.NoError(err)
+		out := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(d), &out))
+		assert.Equal("InPlay", out["gameStatus"])
+		assert.EqualValues(2, out["attemptsUsed"])
+	}
+
+	_, err = MarshalGame(nil)
+	assert.ErrorIs(err, ErrSerialization)
+	_, err = UnmarshalGame([]byte("{"))
+	assert.ErrorIs(err, ErrSerialization)
+}
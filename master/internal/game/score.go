@@ -0,0 +1,57 @@
+package game
+
+import "unicode/utf8"
+
+// Returns the hints guess receives against secret. Both words are validated
+// the same way as in a game of the secret's length: the guess must be a
+// dictionary word.
+func ScorePattern(secret string, guess string) ([]LetterHint, error) {
+	length := utf8.RuneCountInString(secret)
+	if length < 1 {
+		return nil, ErrWordLength
+	}
+	sw, err := validateWordLength(secret, length, secret)
+	if err != nil {
+		return nil, err
+	}
+	gw, err := validateWordLength(guess, length, sw)
+	if err != nil {
+		return nil, err
+	}
+
+	score := make([]LetterHint, length)
+	probe := wordleGame{SecretWord: sw, WordLength: length}
+	if err := probe.scoreWord(gw, &score); err != nil {
+		return nil, err
+	}
+
+	return score, nil
+}
+
+// Returns the hints guess would receive against a candidate secret, without
+// creating a game. Unlike ScorePattern, the secret must also be a valid word.
+func PreviewHints(secret string, guess string) ([]LetterHint, error) {
+	score, err := ScorePattern(secret, guess)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validateWordLength(secret, len(score)); err != nil {
+		return nil, err
+	}
+
+	return score, nil
+}
+
+// Scores each guess against one secret, failing on the first invalid guess
+func ScoreMany(secret string, guesses []string) ([][]LetterHint, error) {
+	scores := make([][]LetterHint, 0, len(guesses))
+	for _, guess := range guesses {
+		score, err := ScorePattern(secret, guess)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
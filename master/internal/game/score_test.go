@@ -0,0 +1,128 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"aluance.io/wordleserver/internal/dictionary"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScorePattern(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		secret string
+		guess  string
+		result []LetterHint
+		err    error
+	}{
+		{secret: "happy", guess: "heave", result: []LetterHint{Green, Grey, Yellow, Grey, Grey}},
+		{secret: "HAPPY", guess: "Puppy", result: []LetterHint{Grey, Grey, Green, Green, Green}},
+		{secret: "happy", guess: "happy", result: []LetterHint{Green, Green, Green, Green, Green}},
+		{secret: "happy", guess: "zzzzz", err: ErrInvalidWord},
+		{secret: "happy", guess: "hap", err: ErrWordLength},
+		{secret: "hap", guess: "happy", err: ErrWordLength},
+		{secret: "pear", guess: "bear", result: []LetterHint{Grey, Green, Green, Green}},
+		{secret: "", guess: "", err: ErrWordLength},
+	}
+
+	for _, test := range tests {
+		score, err := ScorePattern(test.secret, test.guess)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err)
+		assert.Equal(test.result, score, test.guess)
+	}
+}
+
+func TestScoreMany(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	guesses := []string{"heave", "paint", "puppy", "seven", "happy"}
+	scores, err := ScoreMany("happy", guesses)
+	require.NoError(err)
+	require.Len(scores, len(guesses))
+
+	// Matches scoring each guess individually
+	for i, guess := range guesses {
+		score, err := ScorePattern("happy", guess)
+		require.NoError(err)
+		assert.Equal(score, scores[i], guess)
+	}
+
+	scores, err = ScoreMany("happy", []string{})
+	assert.NoError(err)
+	assert.Empty(scores)
+
+	_, err = ScoreMany("happy", []string{"heave", "zzzzz"})
+	assert.ErrorIs(err, ErrInvalidWord)
+}
+
+func TestPreviewHints(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		secret string
+		guess  string
+		err    error
+	}{
+		{secret: "happy", guess: "heave"},
+		{secret: "happy", guess: "paint"},
+		{secret: "happy", guess: "happy"},
+		{secret: "zzzzz", guess: "happy", err: ErrInvalidWord},
+		{secret: "happy", guess: "zzzzz", err: ErrInvalidWord},
+		{secret: "hap", guess: "happy", err: ErrWordLength},
+		{secret: "happy", guess: "happier", err: ErrWordLength},
+	}
+
+	for _, test := range tests {
+		hints, err := PreviewHints(test.secret, test.guess)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, test.secret+"/"+test.guess)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err)
+
+		// The preview matches the hints a game would give
+		game, err := Create(test.secret)
+		require.NoError(err, "Create() returned error when creating Game")
+		_, err = game.Play(test.guess)
+		require.NoError(err)
+		m, err := game.HintMatrix()
+		require.NoError(err)
+		assert.Equal(m[0], hints, test.guess)
+	}
+}
+
+func TestScoreMatchesSolverPattern(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The solver scores with dictionary.Pattern, so both scorers must agree,
+	// including on repeated and accented letters
+	words := []string{"happy", "puppy", "papal", "apple", "allay", "llama", "eerie", "geese", "sheep", "abbey", "babes", "crème", "rêver", "évier"}
+	answers, err := dictionary.Words()
+	require.NoError(err)
+	for i := 0; i < len(answers); i += 97 {
+		words = append(words, answers[i])
+	}
+
+	for _, secret := range words {
+		for _, guess := range words {
+			if len([]rune(secret)) != len([]rune(guess)) {
+				continue
+			}
+			score := make([]LetterHint, len([]rune(secret)))
+			probe := wordleGame{SecretWord: strings.ToUpper(secret)}
+			require.NoError(probe.scoreWord(strings.ToUpper(guess), &score))
+			assert.Equal(dictionary.Pattern(secret, guess), EncodeHints(score), "%s/%s", secret, guess)
+		}
+	}
+}
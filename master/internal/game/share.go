@@ -0,0 +1,352 @@
+package game
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/store"
+)
+
+const SHARE_CODE_LENGTH = 8
+const shareCodePrefix = store.IndexPrefix + "share-"
+
+var mapLetterHintToEmoji = map[LetterHint]string{
+	Green:  "🟩",
+	Yellow: "🟨",
+	Grey:   "⬜",
+}
+
+// High contrast squares, for players who can't tell green from yellow
+var mapLetterHintToColorblindEmoji = map[LetterHint]string{
+	Green:  "🟧",
+	Yellow: "🟦",
+	Grey:   "⬜",
+}
+
+// Dark mode grids use a black square for grey
+var mapEmojiToLetterHint = map[rune]LetterHint{
+	'🟩': Green,
+	'🟨': Yellow,
+	'⬜': Grey,
+	'⬛': Grey,
+	'🟧': Green,
+	'🟦': Yellow,
+}
+
+// Options for rendering a share grid
+type ShareOptions struct {
+	ColorblindMode bool `json:"colorblindMode,omitempty"` // use high contrast orange and blue squares
+}
+
+// Descriptions of each hint for the accessible rendering legend
+var accessibleLegend = []struct {
+	hint LetterHint
+	text string
+}{
+	{Green, "correct letter and position"},
+	{Yellow, "correct letter, wrong position"},
+	{Grey, "letter not in word"},
+}
+
+// Anonymous stats derived from a share grid
+type GridStats struct {
+	Guesses int  `json:"guesses"`
+	Solved  bool `json:"solved"`
+}
+
+// Returns the emoji grid for a finished game. The grid is computed once when
+// the game finishes and cached on the game as ShareText.
+func (g *wordleGame) ShareGrid() (string, error) {
+	if g.GameStatus == InPlay {
+		return "", ErrGameInPlay
+	}
+
+	if len(g.ShareText) < 1 {
+		g.ShareText = g.shareText()
+	}
+
+	return g.ShareText, nil
+}
+
+// Returns the emoji grid for a finished game, rendered with opts. Without
+// options this is the same as ShareGrid.
+func (g *wordleGame) ShareString(opts ShareOptions) (string, error) {
+	if !opts.ColorblindMode {
+		return g.ShareGrid()
+	}
+	if g.GameStatus == InPlay {
+		return "", ErrGameInPlay
+	}
+
+	return g.renderShare(mapLetterHintToColorblindEmoji), nil
+}
+
+// Renders the board using the symbols in config.CONFIG_COLORBLIND_SYMBOLS
+// instead of colors, preceded by a legend
+func (g wordleGame) RenderAccessible() (string, error) {
+	symbols := accessibleSymbols()
+
+	var sb strings.Builder
+	for _, l := range accessibleLegend {
+		sb.WriteString(fmt.Sprintf("%s %s\n", symbols[l.hint], l.text))
+	}
+
+	for _, a := range g.Attempts {
+		if !a.IsValidWord {
+			continue
+		}
+
+		sb.WriteString("\n")
+		sb.WriteString(a.word())
+		sb.WriteString(" ")
+		for _, h := range a.TryResult {
+			sb.WriteString(symbols[h])
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func accessibleSymbols() map[LetterHint]string {
+	r := []rune(config.CONFIG_COLORBLIND_SYMBOLS)
+	symbols := map[LetterHint]string{}
+	for i, l := range accessibleLegend {
+		if i < len(r) {
+			symbols[l.hint] = string(r[i])
+		}
+	}
+
+	return symbols
+}
+
+func (g wordleGame) shareText() string {
+	return g.renderShare(mapLetterHintToEmoji)
+}
+
+// Renders the share header and a row of emoji for each valid attempt
+func (g wordleGame) renderShare(emoji map[LetterHint]string) string {
+	score := "X"
+	if g.GameStatus == Won {
+		score = fmt.Sprint(g.ValidAttempts)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Wordle ")
+	if g.Daily {
+		sb.WriteString(fmt.Sprintf("%d ", g.PuzzleNumber))
+	}
+	sb.WriteString(fmt.Sprintf("%s/%d", score, g.guessLimit()))
+
+	for _, a := range g.Attempts {
+		if !a.IsValidWord {
+			continue // invalid words are not part of the grid
+		}
+
+		sb.WriteString("\n")
+		for _, h := range a.TryResult {
+			sb.WriteString(emoji[h])
+		}
+	}
+
+	return sb.String()
+}
+
+// Returns a short, human-typeable code for the game. The code is registered as
+// an index entry of the game store so that it can be resolved with
+// ResolveShareCode.
+func (g *wordleGame) ShareCode() (string, error) {
+	if len(g.ShareKey) > 0 {
+		return g.ShareKey, nil
+	}
+
+	s, err := store.WordleStore()
+	if err != nil {
+		return "", err
+	}
+
+	// Rehash with an increasing sequence until the code is unused (or already ours)
+	for seq := 0; ; seq++ {
+		code := shareCode(g.Id, seq)
+
+		content, err := s.Load(shareCodePrefix + code)
+		if err != nil {
+			return "", err
+		}
+		if id, ok := shareCodeTarget(s, content); ok && id != g.Id {
+			continue // collision
+		}
+
+		if err := s.Save(shareCodePrefix+code, g.Id); err != nil {
+			return "", err
+		}
+		if _, err := g.update((*wordleGame).setShareKey, code); err != nil {
+			return "", err
+		}
+		if g.ShareKey != code {
+			// A concurrent call registered another code first
+			s.Delete(shareCodePrefix + code)
+		}
+		break
+	}
+
+	return g.ShareKey, nil
+}
+
+// Sets the share code, keeping any code already set
+func (g *wordleGame) setShareKey(code string) (string, error) {
+	if len(g.ShareKey) < 1 {
+		g.ShareKey = code
+		g.LastUpdated = now()
+	}
+
+	return g.ShareKey, nil
+}
+
+// Looks up the game registered under a share code
+func ResolveShareCode(code string) (Game, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != SHARE_CODE_LENGTH {
+		return nil, ErrShareCode
+	}
+
+	s, err := store.WordleStore()
+	if err != nil {
+		return nil, err
+	}
+	content, err := s.Load(shareCodePrefix + code)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := shareCodeTarget(s, content)
+	if !ok {
+		return nil, ErrShareCode
+	}
+
+	return Retrieve(id)
+}
+
+// Returns the game ID registered under a share code, from the content loaded
+// for it from s. Persistent stores return the encoded ID.
+func shareCodeTarget(s store.Store, content interface{}) (string, bool) {
+	switch c := content.(type) {
+	case string:
+		return c, true
+	case []byte:
+		var id string
+		var err error
+		if d, ok := s.(store.Decoder); ok {
+			err = d.Decode(c, &id)
+		} else {
+			err = json.Unmarshal(c, &id)
+		}
+		return id, err == nil
+	}
+
+	return "", false
+}
+
+func shareCode(id string, seq int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", id, seq)))
+	return base32.StdEncoding.EncodeToString(sum[:])[:SHARE_CODE_LENGTH]
+}
+
+// Derives aggregatable stats from a pasted share grid
+func IngestShareGrid(s string) (GridStats, error) {
+	rows, err := ParseShareGrid(s)
+	if err != nil {
+		return GridStats{}, err
+	}
+
+	return GridStats{Guesses: len(rows), Solved: isSolvedRow(rows[len(rows)-1])}, nil
+}
+
+// Parses a share grid into the hint rows it depicts. The "Wordle n/6" header
+// line is optional; when present its score must agree with the rows.
+func ParseShareGrid(s string) ([][]LetterHint, error) {
+	header := ""
+	rows := [][]LetterHint{}
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) < 1 {
+			continue
+		}
+		if strings.HasPrefix(line, "Wordle") {
+			if len(header) > 0 || len(rows) > 0 {
+				return nil, ErrShareGrid
+			}
+			header = line
+			continue
+		}
+
+		row := []LetterHint{}
+		for _, r := range line {
+			h, ok := mapEmojiToLetterHint[r]
+			if !ok {
+				return nil, ErrShareGrid
+			}
+			row = append(row, h)
+		}
+		// Every row has the word length of the game, whichever it is
+		if len(row) < 1 || (len(rows) > 0 && len(row) != len(rows[0])) {
+			return nil, ErrShareGrid
+		}
+		rows = append(rows, row)
+	}
+
+	// Only the last row may be solved, and there must be at least one row
+	if len(rows) < 1 || len(rows) > config.CONFIG_GAME_MAXVALIDATTEMPTS {
+		return nil, ErrShareGrid
+	}
+	for _, row := range rows[:len(rows)-1] {
+		if isSolvedRow(row) {
+			return nil, ErrShareGrid
+		}
+	}
+
+	if len(header) > 0 {
+		if err := checkShareHeader(header, rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+func checkShareHeader(header string, rows [][]LetterHint) error {
+	fields := strings.Fields(header)
+	score := strings.Split(fields[len(fields)-1], "/")
+	if len(score) != 2 {
+		return ErrShareGrid
+	}
+
+	solved := isSolvedRow(rows[len(rows)-1])
+	if score[0] == "X" {
+		if solved {
+			return ErrShareGrid
+		}
+		return nil
+	}
+
+	n, err := strconv.Atoi(score[0])
+	if err != nil || !solved || n != len(rows) {
+		return ErrShareGrid
+	}
+
+	return nil
+}
+
+func isSolvedRow(row []LetterHint) bool {
+	for _, h := range row {
+		if h != Green {
+			return false
+		}
+	}
+
+	return true
+}
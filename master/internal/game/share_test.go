@@ -0,0 +1,291 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"aluance.io/wordleserver/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareGrid(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		tryWords   []string
+		resign     bool
+		result     string
+		err        error
+	}{
+		{createWord: "happy", tryWords: []string{"puppy"}, err: ErrGameInPlay},
+		{createWord: "happy", tryWords: []string{"puppy", "zzzzz", "happy"}, result: "Wordle 2/6\n⬜⬜🟩🟩🟩\n🟩🟩🟩🟩🟩"},
+		{createWord: "happy", tryWords: []string{"bless"}, resign: true, result: "Wordle X/6\n⬜⬜⬜⬜⬜"},
+	}
+
+	for _, test := range tests {
+		game, err := Create(test.createWord)
+		require.NoError(err, "Create() returned error when creating Game")
+
+		for _, tw := range test.tryWords {
+			game.Play(tw)
+		}
+		if test.resign {
+			_, err = game.Resign()
+			require.NoError(err)
+		}
+
+		s, err := game.ShareGrid()
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err)
+		assert.Equal(test.result, s)
+
+		// The cached text matches a fresh computation
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+		assert.Equal(v.shareText(), v.ShareText)
+
+		// The cached text is present after retrieving the game
+		r, err := Retrieve(v.Id)
+		require.NoError(err)
+		rv, ok := r.(*wordleGame)
+		require.True(ok)
+		assert.Equal(test.result, rv.ShareText)
+	}
+}
+
+func TestShareGridCapped(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Guesses used up by hints aren't counted as available in the header
+	tests := []struct {
+		tryWords []string
+		result   string
+	}{
+		{tryWords: []string{"puppy", "happy"}, result: "Wordle 2/2\n⬜⬜🟩🟩🟩\n🟩🟩🟩🟩🟩"},
+		{tryWords: []string{"puppy", "bless"}, result: "Wordle X/2\n⬜⬜🟩🟩🟩\n⬜⬜⬜⬜⬜"},
+	}
+
+	for _, test := range tests {
+		game, err := CreateWithOptions("happy", GameOptions{MaxAttempts: 3, HintCostsAttempt: true})
+		require.NoError(err, "CreateWithOptions() returned error when creating Game")
+		_, err = game.Hint()
+		require.NoError(err)
+		for _, tw := range test.tryWords {
+			_, err := game.Play(tw)
+			require.NoError(err, tw)
+		}
+		require.True(game.IsFinished())
+
+		s, err := game.ShareGrid()
+		require.NoError(err)
+		assert.Equal(test.result, s)
+	}
+}
+
+func TestShareString(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		tryWords []string
+		opts     ShareOptions
+		result   string
+		err      error
+	}{
+		{tryWords: []string{"heave"}, opts: ShareOptions{}, err: ErrGameInPlay},
+		{tryWords: []string{"heave"}, opts: ShareOptions{ColorblindMode: true}, err: ErrGameInPlay},
+		{tryWords: []string{"heave", "paint", "happy"}, opts: ShareOptions{}, result: "Wordle 3/6\n🟩⬜🟨⬜⬜\n🟨🟩⬜⬜⬜\n🟩🟩🟩🟩🟩"},
+		{tryWords: []string{"heave", "paint", "happy"}, opts: ShareOptions{ColorblindMode: true}, result: "Wordle 3/6\n🟧⬜🟦⬜⬜\n🟦🟧⬜⬜⬜\n🟧🟧🟧🟧🟧"},
+	}
+
+	for _, test := range tests {
+		game, err := Create("happy")
+		require.NoError(err, "Create() returned error when creating Game")
+		for _, tw := range test.tryWords {
+			game.Play(tw)
+		}
+
+		s, err := game.ShareString(test.opts)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err)
+		assert.Equal(test.result, s)
+
+		// Either grid can be ingested
+		stats, err := IngestShareGrid(s)
+		require.NoError(err)
+		assert.Equal(GridStats{Guesses: 3, Solved: true}, stats)
+	}
+}
+
+func TestShareCode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	v, ok := game.(*wordleGame)
+	require.True(ok)
+
+	code, err := game.ShareCode()
+	require.NoError(err)
+	assert.Len(code, SHARE_CODE_LENGTH)
+
+	// The code is stable for the same game
+	again, err := game.ShareCode()
+	assert.NoError(err)
+	assert.Equal(code, again)
+
+	// The code resolves back to the same game, regardless of case
+	for _, c := range []string{code, strings.ToLower(code)} {
+		r, err := ResolveShareCode(c)
+		require.NoError(err)
+		rv, ok := r.(*wordleGame)
+		require.True(ok)
+		assert.Equal(v.Id, rv.Id)
+	}
+
+	// Unknown codes are rejected
+	for _, c := range []string{"", "AAAAAAAA", "SHORT"} {
+		_, err := ResolveShareCode(c)
+		assert.ErrorIs(err, ErrShareCode, c)
+	}
+
+	// Codes aren't listed or counted as games
+	s, err := store.WordleStore()
+	require.NoError(err)
+	ids, err := s.List()
+	require.NoError(err)
+	assert.Contains(ids, v.Id)
+	assert.NotContains(ids, shareCodePrefix+code)
+	n, err := s.Count()
+	require.NoError(err)
+	assert.Len(ids, n)
+}
+
+func TestShareCodeCollision(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	fs, err := store.FileStore(t.TempDir())
+	require.NoError(err)
+
+	// File stores return codes encoded, the in-memory store as saved
+	for _, backend := range []store.Store{nil, fs} {
+		restore := store.SetStore(backend)
+
+		game, err := Create("happy")
+		require.NoError(err, "Create() returned error when creating Game")
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+
+		// Occupy the first code with another game
+		other, err := Create("bless")
+		require.NoError(err)
+		ov, ok := other.(*wordleGame)
+		require.True(ok)
+		s, err := store.WordleStore()
+		require.NoError(err)
+		require.NoError(s.Save(shareCodePrefix+shareCode(v.Id, 0), ov.Id))
+
+		code, err := game.ShareCode()
+		require.NoError(err)
+		assert.Equal(shareCode(v.Id, 1), code)
+
+		r, err := ResolveShareCode(code)
+		require.NoError(err)
+		rv, ok := r.(*wordleGame)
+		require.True(ok)
+		assert.Equal(v.Id, rv.Id)
+
+		restore()
+	}
+}
+
+func TestIngestShareGrid(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		grid   string
+		result GridStats
+		err    error
+	}{
+		{grid: "Wordle 2/6\n⬜⬜🟩🟩🟩\n🟩🟩🟩🟩🟩", result: GridStats{Guesses: 2, Solved: true}},
+		{grid: "Wordle 210 3/6\n\n⬛🟨⬛⬛⬛\n⬛⬛🟩🟩🟩\n🟩🟩🟩🟩🟩\n", result: GridStats{Guesses: 3, Solved: true}},
+		{grid: "🟩🟩🟩🟩🟩", result: GridStats{Guesses: 1, Solved: true}},
+		{grid: "Wordle X/6\n⬜⬜⬜⬜⬜\n🟨⬜⬜⬜⬜", result: GridStats{Guesses: 2, Solved: false}},
+		{grid: "", err: ErrShareGrid},
+		{grid: "Wordle 1/6", err: ErrShareGrid},
+		{grid: "⬜⬜🟩🟩\n🟩🟩🟩🟩", result: GridStats{Guesses: 2, Solved: true}},
+		{grid: "⬜⬜🟩🟩\n🟩🟩🟩🟩🟩", err: ErrShareGrid},
+		{grid: "⬜⬜🟩🟩🟥", err: ErrShareGrid},
+		{grid: "🟩🟩🟩🟩🟩\n🟩🟩🟩🟩🟩", err: ErrShareGrid},
+		{grid: "Wordle 3/6\n⬜⬜🟩🟩🟩\n🟩🟩🟩🟩🟩", err: ErrShareGrid},
+		{grid: "Wordle X/6\n🟩🟩🟩🟩🟩", err: ErrShareGrid},
+		{grid: strings.Repeat("⬜⬜⬜⬜⬜\n", 7), err: ErrShareGrid},
+	}
+
+	for _, test := range tests {
+		stats, err := IngestShareGrid(test.grid)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, test.grid)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err, test.grid)
+		assert.Equal(test.result, stats, test.grid)
+	}
+
+	// Grids produced by ShareGrid can be ingested
+	game, err := Create("happy")
+	assert.NoError(err)
+	game.Play("puppy")
+	game.Play("happy")
+	grid, err := game.ShareGrid()
+	assert.NoError(err)
+	stats, err := IngestShareGrid(grid)
+	assert.NoError(err)
+	assert.Equal(GridStats{Guesses: 2, Solved: true}, stats)
+}
+
+func TestRenderAccessible(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	game.Play("heave")
+	game.Play("zzzzz")
+	game.Play("happy")
+
+	s, err := game.RenderAccessible()
+	require.NoError(err)
+
+	// Each hint has its own glyph and none of them are colored squares
+	symbols := accessibleSymbols()
+	assert.Len(symbols, 3)
+	assert.NotEqual(symbols[Green], symbols[Yellow])
+	assert.NotEqual(symbols[Green], symbols[Grey])
+	assert.NotEqual(symbols[Yellow], symbols[Grey])
+	for _, e := range mapLetterHintToEmoji {
+		assert.NotContains(s, e)
+	}
+
+	g, y, x := symbols[Green], symbols[Yellow], symbols[Grey]
+	lines := strings.Split(s, "\n")
+	require.Len(lines, 6)
+	assert.Equal(g+" correct letter and position", lines[0])
+	assert.Equal(y+" correct letter, wrong position", lines[1])
+	assert.Equal(x+" letter not in word", lines[2])
+	assert.Equal("", lines[3])
+	assert.Equal("HEAVE "+g+x+y+x+x, lines[4])
+	assert.Equal("HAPPY "+strings.Repeat(g, 5), lines[5])
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"context"
+	"errors"
+
+	"aluance.io/wordleserver/internal/store"
+)
+
+// Copies every entry of the active game store into target, so that games held
+// in memory survive a shutdown. Returns the context error if ctx is done
+// before all entries are copied.
+func Shutdown(ctx context.Context, target store.Store) error {
+	s, err := store.WordleStore()
+	if err != nil {
+		return err
+	}
+	ss, ok := s.(store.Snapshotter)
+	if !ok {
+		return ErrNoSnapshot
+	}
+
+	snap, err := ss.Snapshot()
+	if errors.Is(err, store.ErrUnsupported) {
+		return ErrNoSnapshot
+	}
+	if err != nil {
+		return err
+	}
+
+	for id, content := range snap {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := target.Save(id, content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"aluance.io/wordleserver/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	saved map[string]interface{}
+}
+
+func (s *fakeStore) Save(id string, content interface{}) error {
+	s.saved[id] = content
+	return nil
+}
+
+func (s *fakeStore) Load(id string) (interface{}, error) { return s.saved[id], nil }
+
+func (s *fakeStore) Exists(id string) (bool, error) {
+	_, ok := s.saved[id]
+	return ok, nil
+}
+
+func (s *fakeStore) Delete(id string) error {
+	delete(s.saved, id)
+	return nil
+}
+
+func (s *fakeStore) PurgeAll() error {
+	s.saved = map[string]interface{}{}
+	return nil
+}
+
+func (s *fakeStore) List() ([]string, error) {
+	ids := []string{}
+	for k := range s.saved {
+		ids = append(ids, k)
+	}
+	return ids, nil
+}
+
+func (s *fakeStore) Update(id string, fn func(content interface{}) (interface{}, error)) error {
+	c, err := fn(s.saved[id])
+	if err != nil {
+		return err
+	}
+	s.saved[id] = c
+	return nil
+}
+
+func (s *fakeStore) Count() (int, error) { return len(s.saved), nil }
+
+func (s *fakeStore) SaveCtx(_ context.Context, id string, content interface{}) error {
+	return s.Save(id, content)
+}
+
+func (s *fakeStore) LoadCtx(_ context.Context, id string) (interface{}, error) {
+	return s.Load(id)
+}
+
+func (s *fakeStore) ExistsCtx(_ context.Context, id string) (bool, error) {
+	return s.Exists(id)
+}
+
+func (s *fakeStore) DeleteCtx(_ context.Context, id string) error {
+	return s.Delete(id)
+}
+
+func (s *fakeStore) UpdateCtx(_ context.Context, id string, fn func(content interface{}) (interface{}, error)) error {
+	return s.Update(id, fn)
+}
+
+func (s *fakeStore) ListPaged(offset, limit int) ([]string, int, error) {
+	ids, _ := s.List()
+	sort.Strings(ids)
+	if offset >= len(ids) {
+		return []string{}, len(ids), nil
+	}
+	if offset+limit < len(ids) {
+		return ids[offset : offset+limit], len(ids), nil
+	}
+	return ids[offset:], len(ids), nil
+}
+
+func TestShutdown(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := store.WordleStore()
+	require.NoError(err)
+	require.NoError(s.PurgeAll())
+
+	ids := []string{}
+	for _, w := range []string{"happy", "bless", "proxy"} {
+		game, err := Create(w)
+		require.NoError(err, "Create() returned error when creating Game")
+		game.Play("puppy")
+		ids = append(ids, game.(*wordleGame).Id)
+	}
+
+	target := &fakeStore{saved: map[string]interface{}{}}
+	require.NoError(Shutdown(context.Background(), target))
+
+	// All games were transferred
+	assert.Len(target.saved, len(ids))
+	for _, id := range ids {
+		g, ok := target.saved[id].(*wordleGame)
+		if assert.True(ok, id) {
+			assert.Equal(id, g.Id)
+			assert.Len(g.Attempts, 1)
+		}
+	}
+
+	// Nothing is transferred once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	target = &fakeStore{saved: map[string]interface{}{}}
+	assert.ErrorIs(Shutdown(ctx, target), context.Canceled)
+	assert.Empty(target.saved)
+}
+
+func TestShutdownAuditingStore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// An auditing store over the memory store still takes snapshots
+	s, err := store.WordleStore()
+	require.NoError(err)
+	require.NoError(s.PurgeAll())
+	var buf bytes.Buffer
+	defer store.SetStore(store.AuditingStore(s, &buf))()
+
+	game, err := CreateWithOptions("happy", GameOptions{TTL: time.Hour})
+	require.NoError(err)
+	id := game.(*wordleGame).Id
+	target := &fakeStore{saved: map[string]interface{}{}}
+	require.NoError(Shutdown(context.Background(), target))
+	assert.Contains(target.saved, id)
+	ids, err := ListByStatus(InPlay)
+	require.NoError(err)
+	assert.Equal([]string{id}, ids)
+
+	// An auditing store over a store without snapshots or TTLs saves plainly
+	fs, err := store.FileStore(t.TempDir())
+	require.NoError(err)
+	defer store.SetStore(store.AuditingStore(fs, &buf))()
+	game, err = CreateWithOptions("happy", GameOptions{TTL: time.Hour})
+	require.NoError(err)
+	_, err = Retrieve(game.(*wordleGame).Id)
+	assert.NoError(err)
+	assert.ErrorIs(Shutdown(context.Background(), target), ErrNoSnapshot)
+	_, err = ListByStatus(InPlay)
+	assert.ErrorIs(err, ErrNoSnapshot)
+}
@@ -0,0 +1,276 @@
+package game
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"aluance.io/wordleserver/internal/config"
+	"aluance.io/wordleserver/internal/dictionary"
+)
+
+// Number of groups a typical guess splits the candidates into, used to
+// estimate how quickly the candidates shrink
+const winBranching = 8
+
+// Semaphore limiting how many solver computations (dictionary scans) run at once
+var solverSem = make(chan struct{}, config.CONFIG_SOLVER_CONCURRENCY)
+var solverMu sync.Mutex
+
+// Set how many solver computations may run at once. Computations already
+// running keep their slot in the previous limit.
+func SetSolverConcurrency(n int) error {
+	if n < 1 {
+		return ErrConcurrency
+	}
+
+	solverMu.Lock()
+	defer solverMu.Unlock()
+	solverSem = make(chan struct{}, n)
+
+	return nil
+}
+
+// Blocks until a solver slot is free, returning the func that releases it
+func acquireSolver() func() {
+	solverMu.Lock()
+	sem := solverSem
+	solverMu.Unlock()
+
+	sem <- struct{}{}
+	return func() { <-sem }
+}
+
+// Suggests a safe word for new players: the unused word consistent with the
+// hints so far whose letters appear in the most other such words, so each
+// guess tests the most common remaining letters. Words with no repeated
+// letters come first, then ties go to the more common word (see
+// dictionary.Rarity) and finally to the alphabetically first.
+func (g wordleGame) SuggestBeginner() (string, error) {
+	if g.GameStatus != InPlay {
+		return "", ErrGameOver
+	}
+
+	release := acquireSolver()
+	defer release()
+
+	cands, err := g.candidates()
+	if err != nil {
+		return "", err
+	}
+
+	used := map[string]bool{}
+	for _, a := range g.Attempts {
+		used[a.word()] = true
+	}
+	words := []string{}
+	for _, w := range cands {
+		if !used[w] {
+			words = append(words, w)
+		}
+	}
+	if len(words) < 1 {
+		return "", ErrInvalidWord
+	}
+
+	if err := rankBeginner(words); err != nil {
+		return "", err
+	}
+
+	return words[0], nil
+}
+
+// Sorts words best first for SuggestBeginner
+func rankBeginner(words []string) error {
+	// Count the words containing each letter
+	frequency := map[rune]int{}
+	for _, w := range words {
+		for r := range letterSet(w) {
+			frequency[r]++
+		}
+	}
+
+	distinct := make(map[string]bool, len(words))
+	coverage := make(map[string]int, len(words))
+	rarity := make(map[string]float64, len(words))
+	for _, w := range words {
+		letters := letterSet(w)
+		distinct[w] = len(letters) == utf8.RuneCountInString(w)
+		for r := range letters {
+			coverage[w] += frequency[r]
+		}
+		r, err := dictionary.Rarity(w)
+		if err != nil {
+			return err
+		}
+		rarity[w] = r
+	}
+	sort.Slice(words, func(i, j int) bool {
+		wi, wj := words[i], words[j]
+		if distinct[wi] != distinct[wj] {
+			return distinct[wi]
+		}
+		if coverage[wi] != coverage[wj] {
+			return coverage[wi] > coverage[wj]
+		}
+		if rarity[wi] != rarity[wj] {
+			return rarity[wi] < rarity[wj]
+		}
+		return wi < wj
+	})
+
+	return nil
+}
+
+func letterSet(w string) map[rune]bool {
+	set := map[rune]bool{}
+	for _, r := range w {
+		set[r] = true
+	}
+
+	return set
+}
+
+func distinctLetters(w string) int {
+	return len(letterSet(w))
+}
+
+// Returns the guesses the solver makes to find the secret word, opening with
+// config.CONFIG_SOLVER_OPENER. The line ends with the secret, so it is only
+// available once the game is finished.
+func (g wordleGame) OptimalLine() ([]string, error) {
+	if !g.IsFinished() {
+		return nil, ErrGameInPlay
+	}
+
+	release := acquireSolver()
+	defer release()
+
+	line, err := dictionary.Solve(g.SecretWord, config.CONFIG_SOLVER_OPENER)
+	if err != nil {
+		return nil, err
+	}
+	for i := range line {
+		line[i] = strings.ToUpper(line[i])
+	}
+
+	return line, nil
+}
+
+// Returns the fraction of guesses in a finished game that coincide, in order,
+// with the optimal line. Identical lines score 1.
+func (g wordleGame) AlignmentScore() (float64, error) {
+	if !g.IsFinished() {
+		return 0, ErrGameInPlay
+	}
+
+	line, err := g.OptimalLine()
+	if err != nil {
+		return 0, err
+	}
+
+	guesses := []string{}
+	for _, a := range g.Attempts {
+		if a.IsValidWord {
+			guesses = append(guesses, a.word())
+		}
+	}
+
+	matches := 0
+	for i := 0; i < len(guesses) && i < len(line); i++ {
+		if guesses[i] == line[i] {
+			matches++
+		}
+	}
+
+	total := len(line)
+	if len(guesses) > total {
+		total = len(guesses)
+	}
+
+	return float64(matches) / float64(total), nil
+}
+
+// Suggests a guess from the remaining candidates only, choosing the one that
+// leaves the smallest expected number of candidates after it is scored. Ties
+// go to the more common word (see dictionary.Rarity), then to the first
+// candidate in dictionary order.
+func (g wordleGame) SuggestCandidateOnly() (string, error) {
+	if g.GameStatus != InPlay {
+		return "", ErrGameOver
+	}
+
+	release := acquireSolver()
+	defer release()
+
+	cands, err := g.candidates()
+	if err != nil {
+		return "", err
+	}
+	if len(cands) < 1 {
+		return "", ErrInvalidWord
+	}
+
+	best, bestScore, bestRarity := "", -1, 0.0
+	score := make([]LetterHint, g.wordLength())
+	for _, guess := range cands {
+		groups := map[string]int{}
+		for _, c := range cands {
+			probe := wordleGame{SecretWord: c}
+			if err := probe.scoreWord(guess, &score); err != nil {
+				return "", err
+			}
+			groups[EncodeHints(score)]++
+		}
+
+		// Expected remaining candidates is proportional to the sum of squares
+		sum := 0
+		for _, n := range groups {
+			sum += n * n
+		}
+		rarity, err := dictionary.Rarity(guess)
+		if err != nil {
+			return "", err
+		}
+		if bestScore < 0 || sum < bestScore || sum == bestScore && rarity < bestRarity {
+			best, bestScore, bestRarity = guess, sum, rarity
+		}
+	}
+
+	return best, nil
+}
+
+// Estimates the probability of finding the secret within the remaining
+// attempts (see RemainingAttempts), from the number of remaining candidates
+func (g wordleGame) WinProbability() (float64, error) {
+	if g.GameStatus != InPlay {
+		return 0, ErrGameOver
+	}
+
+	release := acquireSolver()
+	defer release()
+
+	cands, err := g.candidates()
+	if err != nil {
+		return 0, err
+	}
+
+	return winProbability(len(cands), g.RemainingAttempts()), nil
+}
+
+// Each guess picks one of n candidates, winning with probability 1/n;
+// otherwise the rest shrink by winBranching for the next guess.
+func winProbability(n int, guesses int) float64 {
+	if n < 1 || guesses < 1 {
+		return 0
+	}
+
+	p := 1 / float64(n)
+	if n == 1 {
+		return p
+	}
+	next := (n - 1 + winBranching - 1) / winBranching
+
+	return p + (1-p)*winProbability(next, guesses-1)
+}
@@ -0,0 +1,285 @@
+package game
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aluance.io/wordleserver/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSolverConcurrency(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	defer SetSolverConcurrency(config.CONFIG_SOLVER_CONCURRENCY)
+
+	assert.ErrorIs(SetSolverConcurrency(0), ErrConcurrency)
+	assert.ErrorIs(SetSolverConcurrency(-1), ErrConcurrency)
+
+	const limit = 2
+	require.NoError(SetSolverConcurrency(limit))
+
+	var running, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := acquireSolver()
+			defer release()
+
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(peak, int32(limit))
+	assert.Equal(int32(limit), peak)
+
+	// Solver methods still complete under a limit of one
+	require.NoError(SetSolverConcurrency(1))
+	game, err := Create("happy")
+	require.NoError(err)
+	ok, err := game.IsSolvable()
+	assert.NoError(err)
+	assert.True(ok)
+}
+
+func TestSuggestBeginner(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		tryWords   []string
+		result     string
+		err        error
+	}{
+		{createWord: "happy", result: "AROSE"},
+		{createWord: "happy", tryWords: []string{"bless"}, result: "RATIO"},
+		{createWord: "happy", tryWords: []string{"bless", "puppy"}, result: "HAPPY"}, // every candidate repeats P
+		{createWord: "happy", tryWords: []string{"happy"}, err: ErrGameOver},
+	}
+
+	for _, test := range tests {
+		game, err := Create(test.createWord)
+		require.NoError(err, "Create() returned error when creating Game")
+		for _, tw := range test.tryWords {
+			game.Play(tw)
+		}
+
+		s, err := game.SuggestBeginner()
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err)
+		assert.Equal(test.result, s, test.tryWords)
+
+		// Unused and consistent with the board
+		for _, tw := range test.tryWords {
+			assert.NotEqual(strings.ToUpper(tw), s)
+		}
+		assert.True(game.(*wordleGame).isCandidate(s), s)
+	}
+}
+
+func TestRankBeginner(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Anagrams cover the same letters, so the more common word goes first
+	// even though it isn't alphabetically first
+	words := []string{"EARTH", "HATER", "HEART"}
+	require.NoError(rankBeginner(words))
+	assert.Equal([]string{"HEART", "EARTH", "HATER"}, words)
+
+	// Repeated letters still go last, however common the word
+	words = []string{"HAPPY", "HATER"}
+	require.NoError(rankBeginner(words))
+	assert.Equal([]string{"HATER", "HAPPY"}, words)
+}
+
+func TestAlignmentScore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The optimal line gives away the secret, so it waits for the game to end
+	game, err := Create("proxy")
+	require.NoError(err, "Create() returned error when creating Game")
+	_, err = game.OptimalLine()
+	assert.ErrorIs(err, ErrGameInPlay)
+	_, err = game.AlignmentScore()
+	assert.ErrorIs(err, ErrGameInPlay)
+
+	// A lapsed resignation counts as finished for both
+	lapsed, err := Create("proxy")
+	require.NoError(err)
+	lapseResign(lapsed)
+	_, err = lapsed.OptimalLine()
+	assert.NoError(err)
+	_, err = lapsed.AlignmentScore()
+	assert.NoError(err)
+
+	// Find the optimal line for the test words
+	_, err = game.Resign()
+	require.NoError(err)
+	line, err := game.OptimalLine()
+	require.NoError(err)
+	require.GreaterOrEqual(len(line), 2)
+	assert.Equal(strings.ToUpper(config.CONFIG_SOLVER_OPENER), line[0])
+	assert.Equal("PROXY", line[len(line)-1])
+
+	tests := []struct {
+		tryWords []string
+		result   float64
+	}{
+		{tryWords: line, result: 1.0},
+		{tryWords: append([]string{"bless"}, line[1:]...), result: float64(len(line)-1) / float64(len(line))},
+		{tryWords: []string{"bless", "proxy"}, result: 0},
+	}
+
+	for _, test := range tests {
+		game, err := Create("proxy")
+		require.NoError(err)
+		for _, tw := range test.tryWords {
+			_, err := game.Play(tw)
+			require.NoError(err, tw)
+		}
+
+		score, err := game.AlignmentScore()
+		assert.NoError(err)
+		assert.InDelta(test.result, score, 0.0001, strings.Join(test.tryWords, ","))
+	}
+}
+
+func TestSuggestCandidateOnly(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		createWord string
+		tryWords   []string
+		result     string
+		err        error
+	}{
+		{createWord: "happy", tryWords: []string{"puppy"}},
+		{createWord: "night", tryWords: []string{"bless", "fight"}},
+		{createWord: "night", tryWords: []string{"fight"}, result: "RIGHT"}, // every -IGHT word splits the rest equally
+		{createWord: "match", tryWords: []string{"catch"}, result: "WATCH"}, // more common than BATCH
+		{createWord: "happy", tryWords: []string{"happy"}, err: ErrGameOver},
+	}
+
+	for _, test := range tests {
+		game, err := Create(test.createWord)
+		require.NoError(err, "Create() returned error when creating Game")
+		for _, tw := range test.tryWords {
+			_, err := game.Play(tw)
+			require.NoError(err)
+		}
+
+		s, err := game.SuggestCandidateOnly()
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err)
+		if test.result != "" {
+			assert.Equal(test.result, s, test.tryWords)
+		}
+
+		// The suggestion is itself a candidate
+		v, ok := game.(*wordleGame)
+		require.True(ok)
+		cands, err := v.candidates()
+		require.NoError(err)
+		assert.Contains(cands, s)
+
+		// It is a reasonable choice that splits the remaining candidates
+		p, err := game.GuessPartition(s)
+		require.NoError(err)
+		largest := 0
+		for _, n := range p {
+			if n > largest {
+				largest = n
+			}
+		}
+		assert.Less(largest, len(cands), s)
+	}
+}
+
+func TestWinProbability(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Sensible values for a few board states
+	assert.Equal(1.0, winProbability(1, 1))
+	assert.Equal(0.5, winProbability(2, 1))
+	assert.Less(winProbability(100, 1), 0.05)
+	assert.Less(winProbability(1000, 2), 0.05)
+	assert.Equal(1.0, winProbability(2, 2))
+	assert.Zero(winProbability(0, 6))
+	assert.Zero(winProbability(10, 0))
+
+	// Fewer candidates or more guesses never lower the estimate
+	for n := 1; n < 200; n++ {
+		for r := 1; r <= config.CONFIG_GAME_MAXVALIDATTEMPTS; r++ {
+			p := winProbability(n, r)
+			assert.GreaterOrEqual(p, 0.0)
+			assert.LessOrEqual(p, 1.0)
+			assert.GreaterOrEqual(winProbability(n, r+1), p, "n=%d r=%d", n, r)
+			assert.LessOrEqual(winProbability(n+1, r), p, "n=%d r=%d", n, r)
+		}
+	}
+
+	// The estimate never falls as a game narrows the candidates
+	game, err := Create("happy")
+	require.NoError(err, "Create() returned error when creating Game")
+	last, err := game.WinProbability()
+	require.NoError(err)
+	assert.Greater(last, 0.0)
+	for _, tw := range []string{"bless", "puppy"} {
+		_, err := game.Play(tw)
+		require.NoError(err)
+		p, err := game.WinProbability()
+		require.NoError(err)
+		assert.GreaterOrEqual(p, last, tw)
+		last = p
+	}
+	assert.Equal(1.0, last)
+
+	_, err = game.Play("happy")
+	require.NoError(err)
+	_, err = game.WinProbability()
+	assert.ErrorIs(err, ErrGameOver)
+
+	// Guesses spent on hints aren't available to find the secret
+	game, err = CreateWithOptions("happy", GameOptions{MaxAttempts: 3, HintCostsAttempt: true})
+	require.NoError(err)
+	_, err = game.Play("bless")
+	require.NoError(err)
+	_, err = game.Hint()
+	require.NoError(err)
+	v := game.(*wordleGame)
+	require.Equal(1, v.RemainingAttempts())
+	cands, err := v.candidates()
+	require.NoError(err)
+	require.Greater(len(cands), 1)
+	p, err := game.WinProbability()
+	require.NoError(err)
+	assert.Equal(winProbability(len(cands), 1), p)
+	assert.Less(p, winProbability(len(cands), 2))
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"sort"
+
+	"aluance.io/wordleserver/internal/config"
+)
+
+// Aggregate statistics over a player's games, as shown on a profile
+type Stats struct {
+	Played        int     `json:"played"`
+	Won           int     `json:"won"`
+	WinPercent    float64 `json:"winPercent"`
+	CurrentStreak int     `json:"currentStreak"`
+	MaxStreak     int     `json:"maxStreak"`
+	Distribution  []int   `json:"distribution"` // wins by guesses used; index 0 is a win in one guess
+}
+
+// Computes statistics over finished games, taken in the order they were last
+// updated. Games still in play are ignored. Streaks count consecutive wins, so
+// a lost or resigned game ends a streak. Wins are counted by valid guesses, as
+// in the share grid.
+func Statistics(games []Game) (Stats, error) {
+	finished := []*wordleGame{}
+	for _, game := range games {
+		g, ok := game.(*wordleGame)
+		if !ok || !g.IsFinished() {
+			continue
+		}
+		finished = append(finished, g)
+	}
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finished[i].LastUpdated.Before(finished[j].LastUpdated)
+	})
+
+	s := Stats{Distribution: make([]int, config.CONFIG_GAME_MAXVALIDATTEMPTS)}
+	for _, g := range finished {
+		s.Played++
+		if !g.IsWon() {
+			s.CurrentStreak = 0
+			continue
+		}
+
+		s.Won++
+		s.CurrentStreak++
+		if s.CurrentStreak > s.MaxStreak {
+			s.MaxStreak = s.CurrentStreak
+		}
+		for len(s.Distribution) < g.ValidAttempts {
+			s.Distribution = append(s.Distribution, 0) // games allowing more guesses
+		}
+		if g.ValidAttempts > 0 {
+			s.Distribution[g.ValidAttempts-1]++
+		}
+	}
+	if s.Played > 0 {
+		s.WinPercent = 100 * float64(s.Won) / float64(s.Played)
+	}
+
+	return s, nil
+}
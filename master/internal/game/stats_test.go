@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatistics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Games are played out in the order given, and each finishes later
+	start := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	play := func(i int, status GameStatusType, guesses int) Game {
+		g, err := newGame("happy", 5, false)
+		require.NoError(err)
+		g.GameStatus = status
+		g.ValidAttempts = guesses
+		for n := 0; n < guesses; n++ {
+			g.Attempts = append(g.Attempts, &WordleAttempt{IsValidWord: true})
+		}
+		g.LastUpdated = start.Add(time.Duration(i) * time.Hour)
+		return g
+	}
+
+	tests := []struct {
+		name    string
+		results []GameStatusType
+		guesses []int
+		stats   Stats
+	}{
+		{name: "none", stats: Stats{Distribution: []int{0, 0, 0, 0, 0, 0}}},
+		{
+			name:    "all won",
+			results: []GameStatusType{Won, Won, Won},
+			guesses: []int{3, 4, 3},
+			stats:   Stats{Played: 3, Won: 3, WinPercent: 100, CurrentStreak: 3, MaxStreak: 3, Distribution: []int{0, 0, 2, 1, 0, 0}},
+		},
+		{
+			name:    "streak broken by a resignation",
+			results: []GameStatusType{Won, Won, Won, Resigned, Won, Lost, Won, Won},
+			guesses: []int{1, 2, 6, 2, 4, 6, 5, 2},
+			stats:   Stats{Played: 8, Won: 6, WinPercent: 75, CurrentStreak: 2, MaxStreak: 3, Distribution: []int{1, 2, 0, 1, 1, 1}},
+		},
+		{
+			name:    "games in play are ignored",
+			results: []GameStatusType{Won, InPlay, Lost, InPlay},
+			guesses: []int{2, 1, 6, 3},
+			stats:   Stats{Played: 2, Won: 1, WinPercent: 50, CurrentStreak: 0, MaxStreak: 1, Distribution: []int{0, 1, 0, 0, 0, 0}},
+		},
+		{
+			name:    "wins beyond the default limit",
+			results: []GameStatusType{Won},
+			guesses: []int{8},
+			stats:   Stats{Played: 1, Won: 1, WinPercent: 100, CurrentStreak: 1, MaxStreak: 1, Distribution: []int{0, 0, 0, 0, 0, 0, 0, 1}},
+		},
+	}
+
+	for _, test := range tests {
+		games := []Game{}
+		for i, r := range test.results {
+			games = append(games, play(i, r, test.guesses[i]))
+		}
+
+		s, err := Statistics(games)
+		require.NoError(err, test.name)
+		assert.Equal(test.stats, s, test.name)
+
+		// The order of the slice doesn't matter, only when games finished
+		for i, j := 0, len(games)-1; i < j; i, j = i+1, j-1 {
+			games[i], games[j] = games[j], games[i]
+		}
+		s, err = Statistics(games)
+		require.NoError(err, test.name)
+		assert.Equal(test.stats, s, test.name)
+	}
+
+	// A lapsed resignation counts as finished, and lost
+	s, err := Statistics([]Game{play(0, Won, 3), lapseResign(play(1, InPlay, 2))})
+	require.NoError(err)
+	assert.Equal(Stats{Played: 2, Won: 1, WinPercent: 50, CurrentStreak: 0, MaxStreak: 1, Distribution: []int{0, 0, 1, 0, 0, 0}}, s)
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"time"
+
+	"aluance.io/wordleserver/internal/dictionary"
+)
+
+// Rule allowing missed daily puzzles without breaking a streak
+type FreezeRule struct {
+	Freezes int // missed puzzles forgiven in each period
+	Period  int // puzzles per period; zero or less means one period for all history
+}
+
+// Daily streak, with the puzzles covered by a freeze
+type Streak struct {
+	Current int   `json:"current"`
+	Max     int   `json:"max"`
+	Frozen  []int `json:"frozen,omitempty"` // puzzle numbers missed but forgiven
+}
+
+// Computes the streak of won daily puzzles up to the puzzle of date. A lost or
+// resigned puzzle breaks the streak. A missed puzzle uses up a freeze from its
+// period if one is left and breaks the streak otherwise. The puzzle of date
+// itself may still be played, so missing it does not break the streak.
+// Games that are not daily puzzles, or are still in play, are ignored.
+func DailyStreak(games []Game, date time.Time, rule FreezeRule) (Streak, error) {
+	today, err := dictionary.DailyNumber(date)
+	if err != nil {
+		return Streak{}, err
+	}
+
+	// The best result of each puzzle; a win beats any other finish
+	results := map[int]GameStatusType{}
+	first := today + 1
+	for _, game := range games {
+		g, ok := game.(*wordleGame)
+		if !ok || !g.Daily || !g.IsFinished() || g.PuzzleNumber > today {
+			continue
+		}
+		if s, ok := results[g.PuzzleNumber]; !ok || s != Won {
+			results[g.PuzzleNumber] = g.Status()
+		}
+		if g.PuzzleNumber < first {
+			first = g.PuzzleNumber
+		}
+	}
+
+	streak := Streak{}
+	used := map[int]int{}
+	for n := first; n <= today; n++ {
+		status, played := results[n]
+		switch {
+		case played && status == Won:
+			streak.Current++
+		case played:
+			streak.Current = 0
+		case n == today:
+			// Today's puzzle may still be played
+		case used[rule.period(n)] < rule.Freezes:
+			used[rule.period(n)]++
+			streak.Frozen = append(streak.Frozen, n)
+		default:
+			streak.Current = 0
+		}
+		if streak.Current > streak.Max {
+			streak.Max = streak.Current
+		}
+	}
+
+	return streak, nil
+}
+
+func (r FreezeRule) period(number int) int {
+	if r.Period < 1 {
+		return 0
+	}
+	return number / r.Period
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"aluance.io/wordleserver/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyStreak(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	defer func(e time.Time) { config.CONFIG_DAILY_EPOCH = e }(config.CONFIG_DAILY_EPOCH)
+	config.CONFIG_DAILY_EPOCH = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time {
+		return config.CONFIG_DAILY_EPOCH.AddDate(0, 0, n).Add(9 * time.Hour)
+	}
+
+	// Plays the daily puzzle of each day, winning unless the day is in lose
+	history := func(days []int, lose ...int) []Game {
+		games := []Game{}
+		for _, n := range days {
+			game, err := CreateDaily(day(n))
+			require.NoError(err)
+			v, ok := game.(*wordleGame)
+			require.True(ok)
+			lost := false
+			for _, l := range lose {
+				lost = lost || l == n
+			}
+			if lost {
+				_, err = game.Resign()
+			} else {
+				_, err = game.Play(v.SecretWord)
+			}
+			require.NoError(err)
+			games = append(games, game)
+		}
+		return games
+	}
+
+	// Starts the daily puzzle of a day without finishing it
+	pending := func(n int) Game {
+		game, err := CreateDaily(day(n))
+		require.NoError(err)
+		return game
+	}
+
+	tests := []struct {
+		name   string
+		games  []Game
+		today  int
+		rule   FreezeRule
+		result Streak
+	}{
+		{name: "unbroken", games: history([]int{0, 1, 2}), today: 2, result: Streak{Current: 3, Max: 3}},
+		{name: "today pending", games: history([]int{0, 1, 2}), today: 3, result: Streak{Current: 3, Max: 3}},
+		{name: "skip without freeze", games: history([]int{0, 1, 3, 4}), today: 4, result: Streak{Current: 2, Max: 2}},
+		{name: "skip with freeze", games: history([]int{0, 1, 3, 4}), today: 4, rule: FreezeRule{Freezes: 1},
+			result: Streak{Current: 4, Max: 4, Frozen: []int{2}}},
+		{name: "freeze exhausted", games: history([]int{0, 2, 4, 5}), today: 5, rule: FreezeRule{Freezes: 1},
+			result: Streak{Current: 2, Max: 2, Frozen: []int{1}}},
+		{name: "freeze renewed each period", games: history([]int{0, 2, 4, 5}), today: 5, rule: FreezeRule{Freezes: 1, Period: 3},
+			result: Streak{Current: 4, Max: 4, Frozen: []int{1, 3}}},
+		{name: "loss breaks streak", games: history([]int{0, 1, 2, 3}, 2), today: 3, rule: FreezeRule{Freezes: 1},
+			result: Streak{Current: 1, Max: 2}},
+		{name: "empty", games: []Game{}, today: 3, result: Streak{}},
+		{name: "lapsed resignation breaks streak", games: append(history([]int{0, 1, 2}), lapseResign(pending(3))), today: 3,
+			result: Streak{Current: 0, Max: 3}},
+	}
+
+	for _, test := range tests {
+		streak, err := DailyStreak(test.games, day(test.today), test.rule)
+		require.NoError(err, test.name)
+		assert.Equal(test.result, streak, test.name)
+	}
+
+	_, err := DailyStreak(nil, day(-1), FreezeRule{})
+	assert.Error(err)
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"aluance.io/wordleserver/internal/dictionary"
+)
+
+const (
+	trapYellowRate = 0.75 // share of appearances that must be yellow-only
+	trapMinGuesses = 10   // appearances needed before a letter is judged
+)
+
+// Returns the letters of secret that common guesses usually place in the
+// wrong position, so they show up yellow far more often than green. The most
+// misleading letters come first. Every dictionary word of the secret's length
+// is used as a sample guess.
+func TrapLetters(secret string) ([]rune, error) {
+	length := utf8.RuneCountInString(secret)
+	sw, err := validateWordLength(secret, length, secret)
+	if err != nil {
+		return nil, err
+	}
+	words, err := dictionary.WordsOfLength(length)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) < 1 {
+		return nil, ErrWordLength // no game uses this length
+	}
+
+	// Per letter of the secret, the guesses containing it and those where it
+	// was only ever yellow
+	seen, yellow := map[rune]int{}, map[rune]int{}
+	probe := wordleGame{SecretWord: sw}
+	score := make([]LetterHint, length)
+	for _, w := range words {
+		w = strings.ToUpper(w)
+		if err := probe.scoreWord(w, &score); err != nil {
+			return nil, err
+		}
+
+		hints := map[rune]LetterHint{}
+		for i, r := range []rune(w) {
+			if !strings.ContainsRune(sw, r) {
+				continue
+			}
+			if hintStrength(score[i]) > hintStrength(hints[r]) {
+				hints[r] = score[i]
+			}
+		}
+		for r, h := range hints {
+			seen[r]++
+			if h == Yellow {
+				yellow[r]++
+			}
+		}
+	}
+
+	traps := []rune{}
+	for r, n := range seen {
+		if n >= trapMinGuesses && float64(yellow[r])/float64(n) >= trapYellowRate {
+			traps = append(traps, r)
+		}
+	}
+	rate := func(r rune) float64 { return float64(yellow[r]) / float64(seen[r]) }
+	sort.Slice(traps, func(i, j int) bool {
+		if rate(traps[i]) != rate(traps[j]) {
+			return rate(traps[i]) > rate(traps[j])
+		}
+		return traps[i] < traps[j]
+	})
+
+	return traps, nil
+}
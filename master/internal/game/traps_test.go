@@ -0,0 +1,47 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrapLetters(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		secret   string
+		traps    []rune // letters expected among the traps
+		notTraps []rune
+		err      error
+	}{
+		{secret: "ocean", traps: []rune{'O', 'C'}},                   // O and C usually open a word
+		{secret: "adieu", traps: []rune{'U'}},                        // U rarely ends a word
+		{secret: "bless", traps: []rune{'E'}, notTraps: []rune{'S'}}, // E usually ends a word
+		{secret: "happy", notTraps: []rune{'H', 'A', 'P', 'Y'}},
+		{secret: "", err: ErrWordLength},
+		{secret: "abcdefghijklmnopqrstuvwxyz", err: ErrWordLength},
+	}
+
+	for _, test := range tests {
+		traps, err := TrapLetters(test.secret)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue // This test returned a valid error so move to the next test
+		}
+		require.NoError(err, test.secret)
+
+		for _, r := range traps {
+			assert.True(strings.ContainsRune(strings.ToUpper(test.secret), r), string(r))
+		}
+		for _, r := range test.traps {
+			assert.Contains(traps, r, test.secret)
+		}
+		for _, r := range test.notTraps {
+			assert.NotContains(traps, r, test.secret)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package game
+
+// Takes back the last attempt, for practice games created with the AllowUndo
+// option. A game won or lost by that attempt is back in play. Resigned games
+// can't be undone, and neither can daily games, which every player must play
+// by the same rules.
+func (g *wordleGame) Undo() (string, error) {
+	return g.update((*wordleGame).undo, "")
+}
+
+func (g *wordleGame) undo(string) (string, error) {
+	g.settleResign()
+	if !g.Options.AllowUndo || g.Daily {
+		return g.statusReport(), ErrUndoDisabled
+	}
+	if g.GameStatus == Resigned {
+		return g.statusReport(), ErrGameOver
+	}
+	if len(g.Attempts) < 1 {
+		return g.statusReport(), ErrNoAttempts
+	}
+
+	last := g.Attempts[len(g.Attempts)-1]
+	g.Attempts = g.Attempts[:len(g.Attempts)-1]
+	if last.IsValidWord {
+		g.ValidAttempts--
+	}
+	if g.GameStatus != InPlay {
+		g.setStatus(InPlay)
+		g.ShareText = ""
+	}
+	g.LastUpdated = now()
+
+	return g.statusReport(), nil
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndo(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	game, err := CreateWithOptions("happy", GameOptions{AllowUndo: true})
+	require.NoError(err, "CreateWithOptions() returned error when creating Game")
+	v := game.(*wordleGame)
+
+	_, err = game.Undo()
+	assert.ErrorIs(err, ErrNoAttempts)
+
+	// Undoing a win puts the game back in play
+	game.Play("heave")
+	game.Play("zzzzz")
+	game.Play("happy")
+	require.Equal(Won, v.GameStatus)
+	require.NotEmpty(v.ShareText)
+	_, err = game.KeyboardState()
+	require.NoError(err)
+
+	s, err := game.Undo()
+	require.NoError(err)
+	assert.Equal(InPlay, v.GameStatus)
+	assert.Len(v.Attempts, 2)
+	assert.Equal(1, v.ValidAttempts)
+	assert.Empty(v.ShareText)
+	out := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(s), &out))
+	assert.Equal("InPlay", out["gameStatus"])
+	kb, err := game.KeyboardState()
+	require.NoError(err)
+	assert.Equal(Blank, kb["P"], "keyboard is rebuilt without the undone attempt")
+	assert.Equal(Green, kb["H"])
+
+	// Status changes are logged both ways
+	log := game.Transitions()
+	require.Len(log, 2)
+	assert.Equal(Won, log[0].To)
+	assert.Equal(InPlay, log[1].To)
+
+	// Invalid attempts are undone without changing the valid count
+	_, err = game.Undo()
+	require.NoError(err)
+	assert.Len(v.Attempts, 1)
+	assert.Equal(1, v.ValidAttempts)
+
+	// The undo is saved
+	r, err := Retrieve(v.Id)
+	require.NoError(err)
+	assert.Len(r.(*wordleGame).Attempts, 1)
+
+	_, err = game.Play("happy")
+	require.NoError(err)
+	assert.Equal(Won, v.GameStatus)
+
+	// Resigned games can't be undone
+	game.Undo()
+	game.Resign()
+	_, err = game.Undo()
+	assert.ErrorIs(err, ErrGameOver)
+	assert.Len(v.Attempts, 1)
+
+	// Undo is only for practice games, and never for daily games
+	game, err = Create("happy")
+	require.NoError(err)
+	game.Play("heave")
+	_, err = game.Undo()
+	assert.ErrorIs(err, ErrUndoDisabled)
+	assert.Len(game.(*wordleGame).Attempts, 1)
+
+	daily, err := CreateDaily(time.Date(2022, time.March, 1, 9, 0, 0, 0, time.UTC))
+	require.NoError(err)
+	daily.(*wordleGame).Options.AllowUndo = true
+	daily.Play("heave")
+	_, err = daily.Undo()
+	assert.ErrorIs(err, ErrUndoDisabled)
+}
@@ -0,0 +1,8 @@
+package solver
+
+import "errors"
+
+var (
+	ErrSuggestionCount = errors.New("invalid number of suggestions requested")
+	ErrNoCandidates    = errors.New("no word is consistent with the hints")
+)
@@ -0,0 +1,85 @@
+package solver
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"aluance.io/wordleserver/internal/dictionary"
+	"aluance.io/wordleserver/internal/game"
+)
+
+// Returns up to max words of dict that are consistent with the hints of every
+// valid attempt, best first. A word is consistent if, were it the secret, each
+// attempt would receive exactly the hints it did, so repeated letters are
+// constrained as in scoring. Candidates are ranked by how many other
+// candidates share their distinct letters, so the best guesses test the most
+// common remaining letters.
+func SuggestGuesses(attempts []game.AttemptView, dict []string, max int) ([]string, error) {
+	if max < 1 {
+		return nil, ErrSuggestionCount
+	}
+
+	candidates := []string{}
+	for _, w := range dict {
+		if isConsistent(strings.ToLower(w), attempts) {
+			candidates = append(candidates, w)
+		}
+	}
+	if len(candidates) < 1 {
+		return nil, ErrNoCandidates
+	}
+
+	// Count the candidates containing each letter
+	frequency := map[rune]int{}
+	for _, w := range candidates {
+		for r := range letterSet(w) {
+			frequency[r]++
+		}
+	}
+
+	coverage := make(map[string]int, len(candidates))
+	for _, w := range candidates {
+		for r := range letterSet(w) {
+			coverage[w] += frequency[r]
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if coverage[candidates[i]] != coverage[candidates[j]] {
+			return coverage[candidates[i]] > coverage[candidates[j]]
+		}
+		return candidates[i] < candidates[j]
+	})
+
+	if len(candidates) > max {
+		candidates = candidates[:max]
+	}
+
+	return candidates, nil
+}
+
+// Reports whether word, as the secret, gives every valid attempt its hints
+func isConsistent(word string, attempts []game.AttemptView) bool {
+	for _, a := range attempts {
+		if !a.IsValidWord {
+			continue // invalid words have no hints
+		}
+		if utf8.RuneCountInString(a.TryWord) != utf8.RuneCountInString(word) {
+			return false
+		}
+		if dictionary.Pattern(word, a.TryWord) != game.EncodeHints(a.Hints) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func letterSet(w string) map[rune]bool {
+	set := map[rune]bool{}
+	for _, r := range strings.ToLower(w) {
+		set[r] = true
+	}
+
+	return set
+}
@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"testing"
+
+	"aluance.io/wordleserver/internal/dictionary"
+	"aluance.io/wordleserver/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	G = game.Green
+	Y = game.Yellow
+	X = game.Grey
+)
+
+func view(word string, hints ...game.LetterHint) game.AttemptView {
+	return game.AttemptView{TryWord: word, Hints: hints, IsValidWord: true}
+}
+
+func TestSuggestGuesses(t *testing.T) {
+	assert := assert.New(t)
+
+	dict := []string{"happy", "hippy", "puppy", "poppy", "harpy", "heave", "paper", "apply", "nappy", "sappy"}
+
+	tests := []struct {
+		name     string
+		attempts []game.AttemptView
+		max      int
+		result   []string
+		err      error
+	}{
+		{name: "no hints", max: 3, result: []string{"harpy", "happy", "apply"}},
+		{
+			name:     "repeated grey letter",
+			attempts: []game.AttemptView{view("puppy", X, X, G, G, G)},
+			max:      10,
+			result:   []string{"happy", "nappy", "sappy", "hippy"},
+		},
+		{
+			name: "narrowed to one word",
+			attempts: []game.AttemptView{
+				view("puppy", X, X, G, G, G),
+				view("sappy", X, G, G, G, G),
+				view("nappy", X, G, G, G, G),
+			},
+			max:    10,
+			result: []string{"happy"},
+		},
+		{
+			// One P is yellow and the other grey, so the secret has exactly one P
+			name:     "yellow and grey of the same letter",
+			attempts: []game.AttemptView{view("apply", Y, Y, X, X, G)},
+			max:      10,
+			result:   []string{"harpy"},
+		},
+		{
+			name:     "invalid attempts are ignored",
+			attempts: []game.AttemptView{{TryWord: "ZZZZZ", Hints: []game.LetterHint{0, 0, 0, 0, 0}}, view("HEAVE", G, X, Y, X, X)},
+			max:      10,
+			result:   []string{"harpy", "happy"},
+		},
+		{name: "too few", max: 0, err: ErrSuggestionCount},
+		{name: "inconsistent", attempts: []game.AttemptView{view("happy", X, X, X, X, X)}, max: 1, err: ErrNoCandidates},
+	}
+
+	for _, test := range tests {
+		result, err := SuggestGuesses(test.attempts, dict, test.max)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, test.name)
+			continue // This test returned a valid error so move to the next test
+		}
+		assert.NoError(err, test.name)
+		assert.Equal(test.result, result, test.name)
+	}
+}
+
+func TestSuggestGuessesRunes(t *testing.T) {
+	assert := assert.New(t)
+
+	// Words are compared by letters, not bytes
+	dict := []string{"paint", "éclat"}
+	result, err := SuggestGuesses([]game.AttemptView{view("crème", X, X, X, X, X)}, dict, 10)
+	assert.NoError(err)
+	assert.Equal([]string{"paint"}, result)
+}
+
+func TestSuggestGuessesGame(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g, err := game.Create("happy")
+	require.NoError(err)
+	for _, w := range []string{"heave", "paint", "puppy"} {
+		_, err := g.Play(w)
+		require.NoError(err, w)
+	}
+	attempts, err := g.GetAttempts()
+	require.NoError(err)
+	words, err := dictionary.Words()
+	require.NoError(err)
+
+	// The played hints leave the secret among the suggestions
+	result, err := SuggestGuesses(attempts, words, 100)
+	require.NoError(err)
+	assert.Contains(result, "happy")
+	assert.LessOrEqual(len(result), 5)
+	for _, w := range result {
+		assert.Regexp("^.app[y]$|^happy$", w)
+	}
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Wraps a Store, writing an audit record for every mutation to w as a line
+// of JSON before delegating. Reads are not logged.
+func AuditingStore(s Store, w io.Writer) Store {
+	return &auditingStore{store: s, w: w}
+}
+
+func (s *auditingStore) Save(id string, content interface{}) error {
+	return s.SaveCtx(context.Background(), id, content)
+}
+
+func (s *auditingStore) SaveCtx(ctx context.Context, id string, content interface{}) error {
+	if err := s.auditCtx(ctx, AuditSave, id); err != nil {
+		return err
+	}
+
+	return s.store.SaveCtx(ctx, id, content)
+}
+
+// Saves with the wrapped store's TTL, or returns ErrUnsupported if it can't
+// expire content
+func (s *auditingStore) SaveWithTTL(id string, content interface{}, ttl time.Duration) error {
+	ts, ok := s.store.(TTLSaver)
+	if !ok {
+		return ErrUnsupported
+	}
+	if err := s.audit(AuditSave, id); err != nil {
+		return err
+	}
+
+	return ts.SaveWithTTL(id, content, ttl)
+}
+
+func (s *auditingStore) Load(id string) (interface{}, error) {
+	return s.store.Load(id)
+}
+
+func (s *auditingStore) LoadCtx(ctx context.Context, id string) (interface{}, error) {
+	return s.store.LoadCtx(ctx, id)
+}
+
+func (s *auditingStore) Exists(id string) (bool, error) {
+	return s.store.Exists(id)
+}
+
+func (s *auditingStore) ExistsCtx(ctx context.Context, id string) (bool, error) {
+	return s.store.ExistsCtx(ctx, id)
+}
+
+func (s *auditingStore) Delete(id string) error {
+	return s.DeleteCtx(context.Background(), id)
+}
+
+func (s *auditingStore) DeleteCtx(ctx context.Context, id string) error {
+	if err := s.auditCtx(ctx, AuditDelete, id); err != nil {
+		return err
+	}
+
+	return s.store.DeleteCtx(ctx, id)
+}
+
+func (s *auditingStore) PurgeAll() error {
+	if err := s.audit(AuditPurgeAll, ""); err != nil {
+		return err
+	}
+
+	return s.store.PurgeAll()
+}
+
+func (s *auditingStore) Update(id string, fn func(content interface{}) (interface{}, error)) error {
+	return s.UpdateCtx(context.Background(), id, fn)
+}
+
+func (s *auditingStore) UpdateCtx(ctx context.Context, id string, fn func(content interface{}) (interface{}, error)) error {
+	if err := s.auditCtx(ctx, AuditUpdate, id); err != nil {
+		return err
+	}
+
+	return s.store.UpdateCtx(ctx, id, fn)
+}
+
+func (s *auditingStore) List() ([]string, error) {
+	return s.store.List()
+}
+
+func (s *auditingStore) ListPaged(offset, limit int) ([]string, int, error) {
+	return s.store.ListPaged(offset, limit)
+}
+
+func (s *auditingStore) Count() (int, error) {
+	return s.store.Count()
+}
+
+// Returns the wrapped store's snapshot, or ErrUnsupported if it can't take one
+func (s *auditingStore) Snapshot() (map[string]interface{}, error) {
+	ss, ok := s.store.(Snapshotter)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	return ss.Snapshot()
+}
+
+// Decodes with the wrapped store's codec, or as JSON if it has none
+func (s *auditingStore) Decode(data []byte, v interface{}) error {
+	if d, ok := s.store.(Decoder); ok {
+		return d.Decode(data, v)
+	}
+
+	return JSONCodec.Unmarshal(data, v)
+}
+
+/////////////////
+
+const (
+	AuditSave     = "save"
+	AuditUpdate   = "update"
+	AuditDelete   = "delete"
+	AuditPurgeAll = "purgeAll"
+)
+
+type AuditRecord struct {
+	TimeStamp time.Time `json:"timeStamp"`
+	Operation string    `json:"operation"`
+	Id        string    `json:"id,omitempty"`
+}
+
+type auditingStore struct {
+	store Store
+	mu    sync.Mutex // serializes writes to w
+	w     io.Writer
+}
+
+// Audits a mutation unless ctx is done, so a cancelled mutation isn't logged
+func (s *auditingStore) auditCtx(ctx context.Context, op string, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.audit(op, id)
+}
+
+func (s *auditingStore) audit(op string, id string) error {
+	b, err := json.Marshal(AuditRecord{TimeStamp: time.Now(), Operation: op, Id: id})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
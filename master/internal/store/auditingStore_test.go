@@ -0,0 +1,107 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditingStore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	ws, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+
+	var buf bytes.Buffer
+	store := AuditingStore(ws, &buf)
+
+	// Run a sequence of mutations and reads
+	require.NoError(store.Save("1a2b3c4d5e", "This is the first content"))
+	require.NoError(store.Save("2a4b6c8d0e", "This is the second content"))
+	_, err = store.Load("1a2b3c4d5e")
+	require.NoError(err)
+	_, err = store.Exists("2a4b6c8d0e")
+	require.NoError(err)
+	require.NoError(store.Delete("1a2b3c4d5e"))
+	require.NoError(store.PurgeAll())
+
+	tests := []AuditRecord{
+		{Operation: AuditSave, Id: "1a2b3c4d5e"},
+		{Operation: AuditSave, Id: "2a4b6c8d0e"},
+		{Operation: AuditDelete, Id: "1a2b3c4d5e"},
+		{Operation: AuditPurgeAll},
+	}
+
+	records := []AuditRecord{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var r AuditRecord
+		require.NoError(json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.Len(records, len(tests))
+
+	for i, test := range tests {
+		assert.Equal(test.Operation, records[i].Operation)
+		assert.Equal(test.Id, records[i].Id)
+		assert.False(records[i].TimeStamp.IsZero())
+		if i > 0 {
+			assert.False(records[i].TimeStamp.Before(records[i-1].TimeStamp))
+		}
+	}
+
+	// Mutations are still delegated to the wrapped store
+	v, ok := ws.(*wordleStore)
+	require.True(ok)
+	assert.Zero(len(v.games))
+}
+
+func TestAuditingStoreOptional(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	ws, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+
+	// TTLs and snapshots are forwarded to a store implementing them
+	var buf bytes.Buffer
+	store := AuditingStore(ws, &buf)
+	ts, ok := store.(TTLSaver)
+	require.True(ok)
+	require.NoError(ts.SaveWithTTL("1a2b3c4d5e", "This is the first content", time.Hour))
+	v, ok := ws.(*wordleStore)
+	require.True(ok)
+	assert.Contains(v.expires, "1a2b3c4d5e")
+
+	ss, ok := store.(Snapshotter)
+	require.True(ok)
+	snap, err := ss.Snapshot()
+	require.NoError(err)
+	assert.Equal(map[string]interface{}{"1a2b3c4d5e": "This is the first content"}, snap)
+
+	var r AuditRecord
+	require.NoError(json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &r))
+	assert.Equal(AuditSave, r.Operation)
+	assert.Equal("1a2b3c4d5e", r.Id)
+
+	// Otherwise they are refused, and nothing is audited or saved
+	fs, err := FileStore(t.TempDir())
+	require.NoError(err)
+	buf.Reset()
+	store = AuditingStore(fs, &buf)
+	assert.ErrorIs(store.(TTLSaver).SaveWithTTL("1a2b3c4d5e", "This is the first content", time.Hour), ErrUnsupported)
+	_, err = store.(Snapshotter).Snapshot()
+	assert.ErrorIs(err, ErrUnsupported)
+	assert.Zero(buf.Len())
+	ok, err = fs.Exists("1a2b3c4d5e")
+	require.NoError(err)
+	assert.False(ok)
+}
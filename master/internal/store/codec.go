@@ -0,0 +1,72 @@
+package store
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Encodes content for stores that persist it as bytes
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Codec using encoding/json, the default
+var JSONCodec Codec = jsonCodec{}
+
+// Codec using encoding/gob. Smaller and faster than JSON, but only readable
+// by Go and only for exported fields.
+var GobCodec Codec = gobCodec{}
+
+// Option used when constructing a persistent store
+type StoreOption func(*storeOptions)
+
+// Selects the codec used to encode stored content
+func WithCodec(c Codec) StoreOption {
+	return func(o *storeOptions) {
+		if c != nil {
+			o.codec = c
+		}
+	}
+}
+
+/////////////////
+
+type storeOptions struct {
+	codec Codec
+}
+
+func applyOptions(opts []StoreOption) storeOptions {
+	o := storeOptions{codec: JSONCodec}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type codecContent struct {
+	Name     string
+	Count    int
+	Tags     []string
+	Modified time.Time
+}
+
+func TestCodec(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	in := codecContent{Name: "game", Count: 3, Tags: []string{"a", "b"}, Modified: time.Now().UTC().Round(time.Second)}
+
+	tests := []struct {
+		name  string
+		codec Codec
+	}{
+		{name: "json", codec: JSONCodec},
+		{name: "gob", codec: GobCodec},
+	}
+
+	for _, test := range tests {
+		b, err := test.codec.Marshal(in)
+		require.NoError(err, test.name)
+		assert.NotEmpty(b, test.name)
+
+		out := codecContent{}
+		require.NoError(test.codec.Unmarshal(b, &out), test.name)
+		assert.Equal(in, out, test.name)
+
+		assert.Error(test.codec.Unmarshal([]byte("\x00garbage"), &out), test.name)
+	}
+
+	// Options default to JSON, and a nil codec is ignored
+	assert.Equal(JSONCodec, applyOptions(nil).codec)
+	assert.Equal(GobCodec, applyOptions([]StoreOption{WithCodec(GobCodec)}).codec)
+	assert.Equal(JSONCodec, applyOptions([]StoreOption{WithCodec(nil)}).codec)
+}
@@ -3,5 +3,8 @@ package store
 import "errors"
 
 var (
-	ErrInvalidId = errors.New("invalid id")
+	ErrInvalidId   = errors.New("invalid id")
+	ErrInvalidDir  = errors.New("invalid directory")
+	ErrInvalidPage = errors.New("invalid page")
+	ErrUnsupported = errors.New("not supported by the store")
 )
@@ -0,0 +1,302 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Returns a Store that persists each item as a file under dir, named by its
+// ID. Content is encoded with the store's Codec, JSON by default, unless it is
+// already a []byte. Load returns the encoded bytes for the caller to decode.
+func FileStore(dir string, opts ...StoreOption) (Store, error) {
+	if len(dir) < 1 {
+		return nil, ErrInvalidDir
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	o := applyOptions(opts)
+	return &fileStore{dir: dir, codec: o.codec, locks: make(map[string]*idLock)}, nil
+}
+
+func (s *fileStore) Save(id string, content interface{}) error {
+	return s.SaveCtx(context.Background(), id, content)
+}
+
+func (s *fileStore) SaveCtx(ctx context.Context, id string, content interface{}) error {
+	if err := validateFileId(id); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer s.lock(id, true)()
+
+	return s.write(id, content)
+}
+
+func (s *fileStore) Load(id string) (interface{}, error) {
+	return s.LoadCtx(context.Background(), id)
+}
+
+func (s *fileStore) LoadCtx(ctx context.Context, id string) (interface{}, error) {
+	if err := validateFileId(id); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	defer s.lock(id, false)()
+
+	return s.read(id)
+}
+
+func (s *fileStore) Exists(id string) (bool, error) {
+	return s.ExistsCtx(context.Background(), id)
+}
+
+func (s *fileStore) ExistsCtx(ctx context.Context, id string) (bool, error) {
+	if err := validateFileId(id); err != nil {
+		return false, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	defer s.lock(id, false)()
+	_, err := os.Stat(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *fileStore) Delete(id string) error {
+	return s.DeleteCtx(context.Background(), id)
+}
+
+func (s *fileStore) DeleteCtx(ctx context.Context, id string) error {
+	if err := validateFileId(id); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer s.lock(id, true)()
+	err := os.Remove(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrInvalidId
+	}
+
+	return err
+}
+
+func (s *fileStore) PurgeAll() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		id := e.Name()
+		if e.IsDir() || strings.HasSuffix(id, fileStoreTempSuffix) {
+			continue
+		}
+
+		unlock := s.lock(id, true)
+		err := os.Remove(s.path(id))
+		unlock()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Loads, modifies and saves the item id under its lock. fn receives the
+// encoded bytes, as returned by Load.
+func (s *fileStore) Update(id string, fn func(content interface{}) (interface{}, error)) error {
+	return s.UpdateCtx(context.Background(), id, fn)
+}
+
+func (s *fileStore) UpdateCtx(ctx context.Context, id string, fn func(content interface{}) (interface{}, error)) error {
+	if err := validateFileId(id); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer s.lock(id, true)()
+	current, err := s.read(id)
+	if err != nil {
+		return err
+	}
+	content, err := fn(current)
+	if err != nil {
+		return err
+	}
+
+	return s.write(id, content)
+}
+
+// Returns the IDs of all stored items, sorted
+func (s *fileStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, e := range entries {
+		if e.IsDir() || validateFileId(e.Name()) != nil || IsIndexId(e.Name()) {
+			continue
+		}
+		ids = append(ids, e.Name())
+	}
+
+	return ids, nil
+}
+
+func (s *fileStore) ListPaged(offset, limit int) ([]string, int, error) {
+	ids, err := s.List()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return pageIds(ids, offset, limit)
+}
+
+func (s *fileStore) Count() (int, error) {
+	ids, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+// Decodes bytes returned by Load with the store's codec
+func (s *fileStore) Decode(data []byte, v interface{}) error {
+	return s.codec.Unmarshal(data, v)
+}
+
+/////////////////
+
+const fileStoreTempSuffix = ".tmp"
+
+type fileStore struct {
+	dir   string
+	codec Codec
+
+	mu    sync.Mutex // guards locks
+	locks map[string]*idLock
+}
+
+// Lock of one item, kept only while it is held or awaited
+type idLock struct {
+	sync.RWMutex
+	refs int // guarded by fileStore.mu
+}
+
+// Locks id for writing, or for reading if write is false, and returns the
+// function unlocking it. The lock is dropped once nobody holds or awaits it,
+// so IDs no longer in use don't keep one.
+func (s *fileStore) lock(id string, write bool) (unlock func()) {
+	s.mu.Lock()
+	l, ok := s.locks[id]
+	if !ok {
+		l = &idLock{}
+		s.locks[id] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	if write {
+		l.Lock()
+	} else {
+		l.RLock()
+	}
+
+	return func() {
+		if write {
+			l.Unlock()
+		} else {
+			l.RUnlock()
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(s.locks, id)
+		}
+	}
+}
+
+// Reads the item id, or nil if there is none. Called with the lock of id held.
+func (s *fileStore) read(id string) (interface{}, error) {
+	b, err := os.ReadFile(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Encodes and writes the item id. Called with the lock of id held.
+func (s *fileStore) write(id string, content interface{}) error {
+	b, ok := content.([]byte)
+	if !ok {
+		var err error
+		if b, err = s.codec.Marshal(content); err != nil {
+			return err
+		}
+	}
+
+	// Write to a temporary file first so a failed write never leaves a
+	// partial item behind
+	tmp := s.path(id) + fileStoreTempSuffix
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path(id))
+}
+
+func (s *fileStore) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
+
+// Checks that id is usable as a file name within the store directory
+func validateFileId(id string) error {
+	if err := ValidateId(id); err != nil {
+		return err
+	}
+	if id != filepath.Base(id) || id == "." || id == ".." || strings.HasSuffix(id, fileStoreTempSuffix) {
+		return ErrInvalidId
+	}
+
+	return nil
+}
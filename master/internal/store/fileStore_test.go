@@ -0,0 +1,181 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := FileStore("")
+	assert.ErrorIs(err, ErrInvalidDir)
+
+	dir := t.TempDir()
+	s, err := FileStore(dir)
+	require.NoError(err)
+
+	in := codecContent{Name: "game", Count: 3, Tags: []string{"a", "b"}}
+	require.NoError(s.Save("1a2b3c4d5e", in))
+	require.NoError(s.Save("2a4b6c8d0e", []byte("raw content")))
+	require.NoError(s.Save(IndexPrefix+"entry", "1a2b3c4d5e"))
+
+	// A fresh instance on the same directory sees the saved items
+	fresh, err := FileStore(dir)
+	require.NoError(err)
+
+	c, err := fresh.Load("1a2b3c4d5e")
+	require.NoError(err)
+	out := codecContent{}
+	require.NoError(JSONCodec.Unmarshal(c.([]byte), &out))
+	assert.Equal(in, out)
+
+	c, err = fresh.Load("2a4b6c8d0e")
+	require.NoError(err)
+	assert.Equal([]byte("raw content"), c)
+
+	ok, err := fresh.Exists("1a2b3c4d5e")
+	require.NoError(err)
+	assert.True(ok)
+
+	// Missing items load as nil, as with the in-memory store
+	c, err = fresh.Load("missing")
+	assert.NoError(err)
+	assert.Nil(c)
+	ok, err = fresh.Exists("missing")
+	assert.NoError(err)
+	assert.False(ok)
+	assert.ErrorIs(fresh.Delete("missing"), ErrInvalidId)
+
+	ids, err := fresh.List()
+	require.NoError(err)
+	assert.Equal([]string{"1a2b3c4d5e", "2a4b6c8d0e"}, ids)
+	n, err := fresh.Count()
+	require.NoError(err)
+	assert.Equal(2, n)
+
+	require.NoError(fresh.Delete("1a2b3c4d5e"))
+	ok, err = s.Exists("1a2b3c4d5e")
+	require.NoError(err)
+	assert.False(ok)
+
+	require.NoError(s.PurgeAll())
+	ok, err = fresh.Exists("2a4b6c8d0e")
+	require.NoError(err)
+	assert.False(ok)
+	ids, err = fresh.List()
+	require.NoError(err)
+	assert.Empty(ids)
+
+	// IDs must stay within the directory
+	for _, id := range []string{"", ".", "..", "../escape", "a/b", "x.tmp"} {
+		assert.ErrorIs(s.Save(id, in), ErrInvalidId, id)
+		_, err := s.Load(id)
+		assert.ErrorIs(err, ErrInvalidId, id)
+	}
+
+	// The codec option is used to encode content
+	gs, err := FileStore(dir, WithCodec(GobCodec))
+	require.NoError(err)
+	require.NoError(gs.Save("gob", in))
+	c, err = gs.Load("gob")
+	require.NoError(err)
+	out = codecContent{}
+	require.NoError(GobCodec.Unmarshal(c.([]byte), &out))
+	assert.Equal(in, out)
+
+	// Loaded bytes can be decoded by the store itself
+	d, ok := gs.(Decoder)
+	require.True(ok)
+	out = codecContent{}
+	require.NoError(d.Decode(c.([]byte), &out))
+	assert.Equal(in, out)
+}
+
+func TestFileStoreConcurrent(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := FileStore(t.TempDir())
+	require.NoError(err)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(s.Save("shared", codecContent{Name: fmt.Sprint(i), Count: i}))
+
+			// Every load sees a complete item written by one of the workers
+			c, err := s.Load("shared")
+			if assert.NoError(err) && assert.NotNil(c) {
+				out := codecContent{}
+				assert.NoError(JSONCodec.Unmarshal(c.([]byte), &out))
+				assert.Equal(fmt.Sprint(out.Count), out.Name)
+			}
+
+			// Items that were deleted or never existed don't keep a lock
+			id := fmt.Sprintf("item%d", i)
+			assert.NoError(s.Save(id, "content"))
+			assert.NoError(s.Delete(id))
+			_, err = s.Load("missing" + id)
+			assert.NoError(err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Empty(s.(*fileStore).locks)
+	require.NoError(s.PurgeAll())
+	assert.Empty(s.(*fileStore).locks)
+}
+
+func TestFileStoreUpdate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := FileStore(t.TempDir())
+	require.NoError(err)
+
+	// Concurrent updates are not lost
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(s.Update("counter", func(c interface{}) (interface{}, error) {
+				v := codecContent{}
+				if c != nil {
+					if err := JSONCodec.Unmarshal(c.([]byte), &v); err != nil {
+						return nil, err
+					}
+				}
+				v.Count++
+				return v, nil
+			}))
+		}()
+	}
+	wg.Wait()
+
+	c, err := s.Load("counter")
+	require.NoError(err)
+	v := codecContent{}
+	require.NoError(JSONCodec.Unmarshal(c.([]byte), &v))
+	assert.Equal(50, v.Count)
+
+	// Nothing is saved when fn fails
+	fail := errors.New("fail")
+	assert.ErrorIs(s.Update("counter", func(interface{}) (interface{}, error) { return "changed", fail }), fail)
+	c, err = s.Load("counter")
+	require.NoError(err)
+	require.NoError(JSONCodec.Unmarshal(c.([]byte), &v))
+	assert.Equal(50, v.Count)
+
+	assert.ErrorIs(s.Update("../escape", func(c interface{}) (interface{}, error) { return c, nil }), ErrInvalidId)
+}
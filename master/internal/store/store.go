@@ -1,9 +1,84 @@
 package store
 
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Prefix of the IDs of index entries, such as the share codes pointing at
+// games. They are stored like other content, but List, ListPaged and Count
+// skip them.
+const IndexPrefix = "idx-"
+
 type Store interface {
 	Save(id string, content interface{}) error
 	Load(id string) (interface{}, error)
 	Exists(id string) (bool, error)
 	Delete(id string) error
 	PurgeAll() error
+	List() ([]string, error)
+	Count() (int, error)
+
+	// Returns limit IDs starting at offset in the sorted IDs, and the total
+	// number of IDs. An offset past the end returns an empty page.
+	ListPaged(offset, limit int) ([]string, int, error)
+
+	// Atomically loads, modifies and saves content. fn receives the stored
+	// content, or nil if there is none, and returns the content to save.
+	// Nothing is saved if fn returns an error.
+	Update(id string, fn func(content interface{}) (interface{}, error)) error
+
+	// Context-aware variants of the operations above. They return ctx.Err()
+	// without acquiring any locks once ctx is cancelled or past its deadline;
+	// the variants without a context use context.Background().
+	SaveCtx(ctx context.Context, id string, content interface{}) error
+	LoadCtx(ctx context.Context, id string) (interface{}, error)
+	ExistsCtx(ctx context.Context, id string) (bool, error)
+	DeleteCtx(ctx context.Context, id string) error
+	UpdateCtx(ctx context.Context, id string, fn func(content interface{}) (interface{}, error)) error
+}
+
+// Implemented by stores that can return a consistent copy of all content
+type Snapshotter interface {
+	Snapshot() (map[string]interface{}, error)
+}
+
+// Implemented by stores that can expire content after a time-to-live
+type TTLSaver interface {
+	SaveWithTTL(id string, content interface{}, ttl time.Duration) error
+}
+
+// Implemented by stores whose Load returns encoded bytes, decoding them with
+// the codec they were encoded with
+type Decoder interface {
+	Decode(data []byte, v interface{}) error
+}
+
+// Implemented by content that can be deep copied into a snapshot
+type Cloner interface {
+	Clone() interface{}
+}
+
+// Reports whether id is the ID of an index entry
+func IsIndexId(id string) bool {
+	return strings.HasPrefix(id, IndexPrefix)
+}
+
+// Returns the page of ids starting at offset, and the total number of ids
+func pageIds(ids []string, offset, limit int) ([]string, int, error) {
+	if offset < 0 || limit < 1 {
+		return nil, 0, ErrInvalidPage
+	}
+
+	total := len(ids)
+	if offset >= total {
+		return []string{}, total, nil
+	}
+	end := total
+	if limit < total-offset {
+		end = offset + limit
+	}
+
+	return ids[offset:end], total, nil
 }
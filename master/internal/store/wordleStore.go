@@ -1,71 +1,380 @@
 package store
 
 import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"aluance.io/wordleserver/internal/config"
 	"github.com/matryer/resync"
 )
 
+// Returns the registered Store, or the in-memory singleton if none is
+// registered
 func WordleStore() (Store, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	if registry.store != nil {
+		return registry.store, nil
+	}
+
 	ws := getWordleStore()
 	return ws, nil
 }
 
-func (s wordleStore) Save(id string, content interface{}) error {
-	if err := validateId(id); err != nil {
+// Registers s as the Store returned by WordleStore, replacing any earlier
+// registration. A nil s restores the in-memory singleton. A registered store
+// is shared by all games, so it must be safe for concurrent use.
+func RegisterStore(s Store) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.store = s
+}
+
+// Registers s and returns a function restoring the previous registration,
+// so tests can swap backends with defer SetStore(s)()
+func SetStore(s Store) (restore func()) {
+	registry.Lock()
+	defer registry.Unlock()
+	prev := registry.store
+	registry.store = s
+
+	return func() { RegisterStore(prev) }
+}
+
+// Removes any registered Store, so WordleStore returns the in-memory singleton
+func UseDefaultStore() {
+	RegisterStore(nil)
+}
+
+func (s *wordleStore) Save(id string, content interface{}) error {
+	return s.SaveCtx(context.Background(), id, content)
+}
+
+func (s *wordleStore) SaveCtx(ctx context.Context, id string, content interface{}) error {
+	if err := ValidateId(id); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keepTTL(id)
+	s.games[id] = content
+
+	return nil
+}
+
+// Saves content, which expires once ttl has elapsed. Expired content is no
+// longer returned, and is deleted by a background sweeper. Save and Update
+// keep the expiry; saving again with a ttl of zero or less clears it, so the
+// content never expires.
+func (s *wordleStore) SaveWithTTL(id string, content interface{}, ttl time.Duration) error {
+	if err := ValidateId(id); err != nil {
 		return err
 	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ttl <= 0 {
+		s.games[id] = content
+		delete(s.expires, id)
+		return nil
+	}
+	if s.expires == nil {
+		s.expires = make(map[string]time.Time)
+	}
 	s.games[id] = content
+	s.expires[id] = s.now().Add(ttl)
+
+	if s.stop == nil {
+		s.stop = make(chan struct{})
+		go s.sweeper(s.stop, s.sweepEvery())
+	}
 
 	return nil
 }
 
-func (s wordleStore) Load(id string) (interface{}, error) {
-	if err := validateId(id); err != nil {
+// Set the clock the in-memory store expires content by, so tests can control
+// expiry. Passing nil restores time.Now.
+func SetClock(fn func() time.Time) {
+	s := getWordleStore()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = fn
+}
+
+// Stops the background sweeper, if running. Expired content is still hidden
+// from reads, but is no longer deleted.
+func (s *wordleStore) StopSweeper() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+func (s *wordleStore) Load(id string) (interface{}, error) {
+	return s.LoadCtx(context.Background(), id)
+}
+
+func (s *wordleStore) LoadCtx(ctx context.Context, id string) (interface{}, error) {
+	if err := ValidateId(id); err != nil {
 		return nil, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	c, ok := s.games[id]
-	if !ok {
+	if !ok || s.expired(id, s.now()) {
 		return nil, nil
 	}
 
 	return c, nil
 }
 
-func (s wordleStore) Exists(id string) (bool, error) {
-	if err := validateId(id); err != nil {
+func (s *wordleStore) Exists(id string) (bool, error) {
+	return s.ExistsCtx(context.Background(), id)
+}
+
+func (s *wordleStore) ExistsCtx(ctx context.Context, id string) (bool, error) {
+	if err := ValidateId(id); err != nil {
 		return false, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	_, ok := s.games[id]
-	return ok, nil
+	return ok && !s.expired(id, s.now()), nil
+}
+
+func (s *wordleStore) Delete(id string) error {
+	return s.DeleteCtx(context.Background(), id)
 }
 
-func (s wordleStore) Delete(id string) error {
-	if err := validateId(id); err != nil {
+func (s *wordleStore) DeleteCtx(ctx context.Context, id string) error {
+	if err := ValidateId(id); err != nil {
 		return err
 	}
 
-	if _, ok := s.games[id]; ok {
-		delete(s.games, id)
-	} else {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.games[id]
+	expired := s.expired(id, s.now())
+	delete(s.games, id)
+	delete(s.expires, id)
+	if !ok || expired {
 		return ErrInvalidId
 	}
 
 	return nil
 }
 
-func (s wordleStore) PurgeAll() error {
+func (s *wordleStore) PurgeAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	for k, _ := range s.games {
 		delete(s.games, k)
 	}
+	for k := range s.expires {
+		delete(s.expires, k)
+	}
 
 	return nil
 }
 
+// Returns the IDs of all stored content, sorted
+func (s *wordleStore) List() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	ids := make([]string, 0, len(s.games))
+	for k := range s.games {
+		if !IsIndexId(k) && !s.expired(k, now) {
+			ids = append(ids, k)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// Returns a page of the IDs, sorted. Each call lists the IDs afresh, so items
+// saved or deleted between calls shift later pages.
+func (s *wordleStore) ListPaged(offset, limit int) ([]string, int, error) {
+	ids, err := s.List()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return pageIds(ids, offset, limit)
+}
+
+// Returns the number of items stored, not counting index entries. Only
+// content saved with a TTL is checked for expiry.
+func (s *wordleStore) Count() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := 0
+	for k := range s.games {
+		if !IsIndexId(k) {
+			n++
+		}
+	}
+	now := s.now()
+	for k := range s.expires {
+		if !IsIndexId(k) && s.expired(k, now) {
+			n--
+		}
+	}
+
+	return n, nil
+}
+
+// Loads, modifies and saves the content of id under a single write lock
+func (s *wordleStore) Update(id string, fn func(content interface{}) (interface{}, error)) error {
+	return s.UpdateCtx(context.Background(), id, fn)
+}
+
+func (s *wordleStore) UpdateCtx(ctx context.Context, id string, fn func(content interface{}) (interface{}, error)) error {
+	if err := ValidateId(id); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.games[id]
+	if s.expired(id, s.now()) {
+		current = nil
+	}
+	content, err := fn(current)
+	if err != nil {
+		return err
+	}
+	s.keepTTL(id)
+	s.games[id] = content
+
+	return nil
+}
+
+// Returns a point-in-time copy of all stored content, taken under a single
+// read lock. Content implementing Cloner is deep copied.
+func (s *wordleStore) Snapshot() (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	snap := make(map[string]interface{}, len(s.games))
+	for k, v := range s.games {
+		if s.expired(k, now) {
+			continue
+		}
+		if c, ok := v.(Cloner); ok {
+			v = c.Clone()
+		}
+		snap[k] = v
+	}
+
+	return snap, nil
+}
+
 /////////////////
 
 type wordleStore struct {
-	games map[string]interface{}
+	mu      sync.RWMutex // guards games, expires, stop and clock
+	games   map[string]interface{}
+	expires map[string]time.Time // expiry of content saved with a TTL
+	stop    chan struct{}        // closed to stop the sweeper; nil when not running
+
+	sweepInterval time.Duration    // zero uses CONFIG_STORE_SWEEPINTERVAL
+	clock         func() time.Time // nil uses time.Now; set with SetClock
+}
+
+// Reports whether the content of id has expired. Called with mu held.
+func (s *wordleStore) expired(id string, now time.Time) bool {
+	t, ok := s.expires[id]
+	return ok && !now.Before(t)
+}
+
+// Keeps the expiry of id for content saved over it, unless it has already
+// expired, in which case the new content doesn't inherit it. Called with mu
+// held.
+func (s *wordleStore) keepTTL(id string) {
+	if s.expired(id, s.now()) {
+		delete(s.expires, id)
+	}
+}
+
+// Deletes all content that has expired by now
+func (s *wordleStore) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range s.expires {
+		if s.expired(id, now) {
+			delete(s.games, id)
+			delete(s.expires, id)
+		}
+	}
+}
+
+func (s *wordleStore) sweeper(stop <-chan struct{}, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			s.mu.RLock()
+			now := s.now()
+			s.mu.RUnlock()
+			s.sweep(now)
+		}
+	}
+}
+
+func (s *wordleStore) now() time.Time {
+	if s.clock != nil {
+		return s.clock()
+	}
+
+	return time.Now()
+}
+
+func (s *wordleStore) sweepEvery() time.Duration {
+	if s.sweepInterval > 0 {
+		return s.sweepInterval
+	}
+
+	return config.CONFIG_STORE_SWEEPINTERVAL
+}
+
+var registry struct {
+	sync.RWMutex // guards store
+	store        Store
 }
 
 var singleStore *wordleStore
@@ -85,11 +394,15 @@ func getWordleStore() *wordleStore {
 
 // Created to facilitate testing
 func resetWordleStore() {
+	if singleStore != nil {
+		singleStore.StopSweeper()
+	}
 	singleStore = nil
 	once.Reset()
 }
 
-func validateId(id string) error {
+// Checks that id is usable as a store key
+func ValidateId(id string) error {
 	if len(id) < 1 {
 		return ErrInvalidId
 	}
@@ -1,8 +1,15 @@
 package store
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,10 +19,10 @@ func TestWordleStore(t *testing.T) {
 	assert := assert.New(t)
 
 	tests := []struct {
-		result wordleStore
+		result *wordleStore
 		err    error
 	}{
-		{result: wordleStore{games: map[string]interface{}{}}, err: nil},
+		{result: &wordleStore{games: map[string]interface{}{}}, err: nil},
 	}
 
 	for _, test := range tests {
@@ -280,6 +287,247 @@ func TestPurgeAll(t *testing.T) {
 
 }
 
+func TestList(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	store, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+
+	ids, err := store.List()
+	require.NoError(err)
+	assert.Empty(ids)
+
+	for _, id := range []string{"2a4b6c8d0e", "1a2b3c4d5e", "3c6d9e2f5a"} {
+		require.NoError(store.Save(id, "content"))
+	}
+	require.NoError(store.(*wordleStore).SaveWithTTL("expired", "content", time.Nanosecond))
+	defer store.(*wordleStore).StopSweeper()
+	time.Sleep(time.Millisecond)
+	require.NoError(store.Save(IndexPrefix+"entry", "1a2b3c4d5e"))
+
+	// Index entries are skipped
+	ids, err = store.List()
+	require.NoError(err)
+	assert.Equal([]string{"1a2b3c4d5e", "2a4b6c8d0e", "3c6d9e2f5a"}, ids)
+
+	// The returned slice is a copy
+	ids[0] = "changed"
+	again, err := store.List()
+	require.NoError(err)
+	assert.Equal("1a2b3c4d5e", again[0])
+
+	require.NoError(store.PurgeAll())
+	ids, err = store.List()
+	require.NoError(err)
+	assert.Empty(ids)
+}
+
+func TestListPaged(t *testing.T) {
+	tests := []struct {
+		offset int
+		limit  int
+		result []string
+		err    error
+	}{
+		{offset: 0, limit: 2, result: []string{"1a2b3c4d5e", "2a4b6c8d0e"}},
+		{offset: 2, limit: 2, result: []string{"3c6d9e2f5a", "4d8e2f6a0b"}},
+		{offset: 4, limit: 2, result: []string{"5e0f5a0b5c"}},
+		{offset: 0, limit: 10, result: []string{"1a2b3c4d5e", "2a4b6c8d0e", "3c6d9e2f5a", "4d8e2f6a0b", "5e0f5a0b5c"}},
+		{offset: 5, limit: 2, result: []string{}},
+		{offset: 99, limit: 2, result: []string{}},
+		{offset: 1, limit: math.MaxInt, result: []string{"2a4b6c8d0e", "3c6d9e2f5a", "4d8e2f6a0b", "5e0f5a0b5c"}},
+		{offset: -1, limit: 2, err: ErrInvalidPage},
+		{offset: 0, limit: 0, err: ErrInvalidPage},
+	}
+
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	store, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+	fs, err := FileStore(t.TempDir())
+	require.NoError(err)
+
+	for _, s := range []Store{store, fs} {
+		for _, id := range []string{"4d8e2f6a0b", "2a4b6c8d0e", "5e0f5a0b5c", "1a2b3c4d5e", "3c6d9e2f5a"} {
+			require.NoError(s.Save(id, []byte("content")))
+		}
+
+		for _, test := range tests {
+			page, total, err := s.ListPaged(test.offset, test.limit)
+			if test.err != nil {
+				assert.ErrorIs(err, test.err)
+				continue // This test returned a valid error so move to the next test
+			}
+			assert.NoError(err)
+			assert.Equal(test.result, page, "offset %d limit %d", test.offset, test.limit)
+			assert.Equal(5, total, "offset %d limit %d", test.offset, test.limit)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	store, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+	defer store.(*wordleStore).StopSweeper()
+
+	tests := []struct {
+		op    func() error
+		count int
+	}{
+		{op: func() error { return nil }, count: 0},
+		{op: func() error { return store.Save("1a2b3c4d5e", "first") }, count: 1},
+		{op: func() error { return store.Save("2a4b6c8d0e", "second") }, count: 2},
+		{op: func() error { return store.Save("1a2b3c4d5e", "replaced") }, count: 2},
+		{op: func() error { return store.Save("3c6d9e2f5a", "third") }, count: 3},
+		{op: func() error { return store.Delete("2a4b6c8d0e") }, count: 2},
+		{op: func() error { return store.Save(IndexPrefix+"entry", "1a2b3c4d5e") }, count: 2},
+		{op: func() error {
+			err := store.(*wordleStore).SaveWithTTL("expired", "gone", time.Nanosecond)
+			time.Sleep(time.Millisecond)
+			return err
+		}, count: 2},
+		{op: func() error { return store.PurgeAll() }, count: 0},
+	}
+
+	for i, test := range tests {
+		require.NoError(test.op(), i)
+		n, err := store.Count()
+		require.NoError(err, i)
+		assert.Equal(test.count, n, i)
+	}
+
+	// Counting is safe alongside concurrent writes
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("id%d", i)
+			assert.NoError(store.Save(id, i))
+			_, err := store.Count()
+			assert.NoError(err)
+			assert.NoError(store.Delete(id))
+		}(i)
+	}
+	wg.Wait()
+	n, err := store.Count()
+	require.NoError(err)
+	assert.Zero(n)
+}
+
+type cloneable struct {
+	value int
+}
+
+func (c *cloneable) Clone() interface{} {
+	v := *c
+	return &v
+}
+
+func TestSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	store, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+	require.NotNil(store, "instance is nil")
+
+	ids := []string{"1a2b3c4d5e", "2a4b6c8d0e", "3a6b9c2d5e"}
+	for i, id := range ids {
+		require.NoError(store.Save(id, &cloneable{value: i}))
+	}
+	require.NoError(store.Save("4a8b2c6d0e", "plain content"))
+
+	ss, ok := store.(Snapshotter)
+	require.True(ok)
+	snap, err := ss.Snapshot()
+	require.NoError(err)
+	require.Len(snap, len(ids)+1)
+
+	// Mutate the store (and the stored content) while iterating the snapshot
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			store.Save(fmt.Sprintf("x%d", i), i)
+			store.Delete(ids[i%len(ids)])
+			if c, err := store.Load(ids[0]); err == nil && c != nil {
+				c.(*cloneable).value = -1
+			}
+		}
+		store.PurgeAll()
+	}()
+
+	for n := 0; n < 10; n++ {
+		assert.Len(snap, len(ids)+1)
+		for i, id := range ids {
+			assert.Equal(i, snap[id].(*cloneable).value)
+		}
+		assert.Equal("plain content", snap["4a8b2c6d0e"])
+	}
+	<-done
+
+	// The snapshot is unaffected by the mutations
+	assert.Len(snap, len(ids)+1)
+	assert.Equal(0, snap[ids[0]].(*cloneable).value)
+}
+
+func TestUpdate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	store, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+	u := store
+
+	// Concurrent updates are not lost
+	id := "1a2b3c4d5e"
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(u.Update(id, func(c interface{}) (interface{}, error) {
+				n, _ := c.(int)
+				return n + 1, nil
+			}))
+		}()
+	}
+	wg.Wait()
+	c, err := store.Load(id)
+	assert.NoError(err)
+	assert.Equal(100, c)
+
+	// A failed update leaves the content unchanged
+	errTest := errors.New("test error")
+	err = u.Update(id, func(c interface{}) (interface{}, error) {
+		return nil, errTest
+	})
+	assert.ErrorIs(err, errTest)
+	c, err = store.Load(id)
+	assert.NoError(err)
+	assert.Equal(100, c)
+
+	// Missing content is passed as nil
+	err = u.Update("2a4b6c8d0e", func(c interface{}) (interface{}, error) {
+		assert.Nil(c)
+		return "new", nil
+	})
+	assert.NoError(err)
+
+	assert.ErrorIs(u.Update("", func(c interface{}) (interface{}, error) { return c, nil }), ErrInvalidId)
+}
+
 // func createCleanStore() (Store, error) {
 // 	store, err := WordleStore()
 // 	if err != nil {
@@ -294,3 +542,171 @@ func TestPurgeAll(t *testing.T) {
 
 // 	return store, err
 // }
+
+func TestRegisterStore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	def, err := WordleStore()
+	require.NoError(err)
+	require.IsType(&wordleStore{}, def)
+
+	fs, err := FileStore(t.TempDir())
+	require.NoError(err)
+	RegisterStore(fs)
+	s, err := WordleStore()
+	require.NoError(err)
+	assert.Equal(fs, s)
+
+	// SetStore restores the earlier registration
+	other := AuditingStore(def, &bytes.Buffer{})
+	restore := SetStore(other)
+	s, _ = WordleStore()
+	assert.Equal(other, s)
+	restore()
+	s, _ = WordleStore()
+	assert.Equal(fs, s)
+
+	// The default store is unaffected by registrations, even when reset
+	UseDefaultStore()
+	s, _ = WordleStore()
+	assert.Equal(def, s)
+	resetWordleStore()
+	s, _ = WordleStore()
+	assert.NotSame(def, s)
+	assert.IsType(&wordleStore{}, s)
+}
+
+func TestSaveWithTTL(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	defer resetWordleStore()
+	s, err := WordleStore()
+	require.NoError(err)
+	v := s.(*wordleStore)
+	v.sweepInterval = time.Hour // swept explicitly below
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	v.clock = func() time.Time { return now }
+
+	require.NoError(v.SaveWithTTL("expiring", "short lived", time.Minute))
+	require.NoError(v.SaveWithTTL("lasting", "long lived", time.Hour))
+	require.NoError(v.SaveWithTTL("forever", "no ttl", 0))
+	assert.ErrorIs(v.SaveWithTTL("", "bad id", time.Hour), ErrInvalidId)
+
+	// Content is kept until its TTL has elapsed exactly
+	now = now.Add(time.Minute - time.Nanosecond)
+	ok, err := s.Exists("expiring")
+	assert.NoError(err)
+	assert.True(ok)
+	now = now.Add(time.Nanosecond)
+
+	// Expired but not yet swept content is hidden
+	c, err := s.Load("expiring")
+	assert.NoError(err)
+	assert.Nil(c)
+	ok, err = s.Exists("expiring")
+	assert.NoError(err)
+	assert.False(ok)
+	snap, err := v.Snapshot()
+	require.NoError(err)
+	assert.NotContains(snap, "expiring")
+	assert.Len(snap, 2)
+
+	v.sweep(now)
+	assert.NotContains(v.games, "expiring")
+	assert.Contains(v.games, "lasting")
+	assert.Contains(v.games, "forever")
+
+	// Saves and updates keep the TTL
+	require.NoError(s.Save("lasting", "saved again"))
+	require.NoError(s.Update("lasting", func(interface{}) (interface{}, error) { return "updated", nil }))
+	ok, err = s.Exists("lasting")
+	assert.NoError(err)
+	assert.True(ok)
+	v.sweep(now.Add(time.Hour))
+	assert.NotContains(v.games, "lasting")
+
+	// Saving with no TTL clears it
+	require.NoError(v.SaveWithTTL("cleared", "short lived", time.Minute))
+	require.NoError(v.SaveWithTTL("cleared", "now permanent", 0))
+	v.sweep(now.Add(2 * time.Hour))
+	assert.Contains(v.games, "cleared")
+
+	// Content saved over expired content doesn't inherit its TTL
+	require.NoError(v.SaveWithTTL("reused", "short lived", time.Minute))
+	now = now.Add(time.Minute)
+	require.NoError(s.Save("reused", "new content"))
+	v.sweep(now.Add(2 * time.Hour))
+	assert.Contains(v.games, "reused")
+
+	// The background sweeper deletes expired content
+	v.StopSweeper()
+	v.clock = nil
+	v.sweepInterval = time.Millisecond
+	require.NoError(v.SaveWithTTL("swept", "short lived", time.Millisecond))
+	assert.Eventually(func() bool {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		_, ok := v.games["swept"]
+		return !ok
+	}, time.Second, time.Millisecond)
+
+	v.StopSweeper()
+	v.StopSweeper() // stopping twice is harmless
+	v.mu.RLock()
+	assert.Nil(v.stop)
+	v.mu.RUnlock()
+}
+
+func TestContextCancelled(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resetWordleStore()
+	ws, err := WordleStore()
+	require.NoError(err, "error obtaining the instance")
+	fs, err := FileStore(t.TempDir())
+	require.NoError(err)
+	var buf bytes.Buffer
+	audited := AuditingStore(ws, &buf)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	for _, s := range []Store{ws, fs, audited} {
+		require.NoError(s.Save("1a2b3c4d5e", []byte("content")))
+
+		for ctx, want := range map[context.Context]error{cancelled: context.Canceled, expired: context.DeadlineExceeded} {
+			assert.ErrorIs(s.SaveCtx(ctx, "2a4b6c8d0e", []byte("content")), want)
+			c, err := s.LoadCtx(ctx, "1a2b3c4d5e")
+			assert.ErrorIs(err, want)
+			assert.Nil(c)
+			ok, err := s.ExistsCtx(ctx, "1a2b3c4d5e")
+			assert.ErrorIs(err, want)
+			assert.False(ok)
+			assert.ErrorIs(s.DeleteCtx(ctx, "1a2b3c4d5e"), want)
+			called := false
+			assert.ErrorIs(s.UpdateCtx(ctx, "1a2b3c4d5e", func(c interface{}) (interface{}, error) {
+				called = true
+				return c, nil
+			}), want)
+			assert.False(called, "fn is not called once the context is done")
+		}
+
+		// Nothing was changed
+		ok, err := s.Exists("2a4b6c8d0e")
+		require.NoError(err)
+		assert.False(ok)
+		c, err := s.LoadCtx(context.Background(), "1a2b3c4d5e")
+		require.NoError(err)
+		assert.Equal([]byte("content"), c)
+	}
+
+	// Only the plain save was audited
+	assert.Equal(1, strings.Count(buf.String(), "\n"))
+}
@@ -1,7 +1,16 @@
 package main
 
-import "aluance.io/wordleserver/internal/api"
+import (
+	"log"
+
+	"aluance.io/wordleserver/internal/api"
+	"aluance.io/wordleserver/internal/config"
+)
 
 func main() {
+	if err := config.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
 	api.Initialize()
 }